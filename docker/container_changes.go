@@ -0,0 +1,78 @@
+package docker
+
+import (
+	"path"
+	"sort"
+
+	"github.com/docker/docker/api/types/container"
+)
+
+// ChangeGroup counts the changes of the writable layer of a container in one directory.
+type ChangeGroup struct {
+	Dir     string
+	Added   int
+	Changed int
+	Deleted int
+}
+
+func (g ChangeGroup) Total() int {
+	return g.Added + g.Changed + g.Deleted
+}
+
+// ContainerChanges returns the paths added, changed and deleted in the container relative to its image.
+func (d *Docker) ContainerChanges(containerID string) ([]container.FilesystemChange, error) {
+	changes, err := d.cli.ContainerDiff(d.ctx, containerID)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Path < changes[j].Path
+	})
+	return changes, nil
+}
+
+// GroupChanges groups the changes by parent directory, sorted by number of changes.
+// Directories reported as changed only because something inside them changed are not counted.
+func GroupChanges(changes []container.FilesystemChange) []ChangeGroup {
+	parents := map[string]bool{}
+	for _, c := range changes {
+		parents[path.Dir(c.Path)] = true
+	}
+
+	groups := map[string]*ChangeGroup{}
+	for _, c := range changes {
+		if c.Kind == container.ChangeModify && parents[c.Path] {
+			continue
+		}
+
+		dir := path.Dir(c.Path)
+		g, ok := groups[dir]
+		if !ok {
+			g = &ChangeGroup{Dir: dir}
+			groups[dir] = g
+		}
+
+		switch c.Kind {
+		case container.ChangeAdd:
+			g.Added++
+		case container.ChangeModify:
+			g.Changed++
+		case container.ChangeDelete:
+			g.Deleted++
+		}
+	}
+
+	result := []ChangeGroup{}
+	for _, g := range groups {
+		result = append(result, *g)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Total() != result[j].Total() {
+			return result[i].Total() > result[j].Total()
+		}
+		return result[i].Dir < result[j].Dir
+	})
+
+	return result
+}
@@ -0,0 +1,31 @@
+package docker
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/docker/docker/api/types/container"
+)
+
+func TestGroupChanges(t *testing.T) {
+	changes := []container.FilesystemChange{
+		{Kind: container.ChangeModify, Path: "/etc"},
+		{Kind: container.ChangeModify, Path: "/etc/hosts"},
+		{Kind: container.ChangeModify, Path: "/var"},
+		{Kind: container.ChangeModify, Path: "/var/log"},
+		{Kind: container.ChangeAdd, Path: "/var/log/app.log"},
+		{Kind: container.ChangeAdd, Path: "/var/log/error.log"},
+		{Kind: container.ChangeDelete, Path: "/var/log/old.log"},
+		{Kind: container.ChangeAdd, Path: "/data"},
+	}
+
+	want := []ChangeGroup{
+		{Dir: "/var/log", Added: 2, Deleted: 1},
+		{Dir: "/", Added: 1},
+		{Dir: "/etc", Changed: 1},
+	}
+
+	if got := GroupChanges(changes); !reflect.DeepEqual(got, want) {
+		t.Errorf("GroupChanges() = %v, want %v", got, want)
+	}
+}
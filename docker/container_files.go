@@ -0,0 +1,363 @@
+package docker
+
+import (
+	"archive/tar"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/api/types"
+)
+
+type ContainerFile struct {
+	Name       string
+	Path       string
+	Size       int64
+	Mode       fs.FileMode
+	ModTime    time.Time
+	LinkTarget string
+}
+
+func (f ContainerFile) IsDir() bool {
+	return f.Mode.IsDir()
+}
+
+func (d *Docker) ContainerStatPath(containerID string, p string) (ContainerFile, error) {
+	stat, err := d.cli.ContainerStatPath(d.ctx, containerID, p)
+	if err != nil {
+		return ContainerFile{}, err
+	}
+
+	return ContainerFile{
+		Name:       stat.Name,
+		Path:       p,
+		Size:       stat.Size,
+		Mode:       stat.Mode,
+		ModTime:    stat.Mtime,
+		LinkTarget: stat.LinkTarget,
+	}, nil
+}
+
+// ContainerResolvePath returns the file at p, or the file a symlink at p points to.
+func (d *Docker) ContainerResolvePath(containerID string, p string) (ContainerFile, error) {
+	f, err := d.ContainerStatPath(containerID, p)
+	if err != nil || f.Mode&fs.ModeSymlink == 0 {
+		return f, err
+	}
+
+	// the daemon returns the target with all the links resolved
+	return d.ContainerStatPath(containerID, f.LinkTarget)
+}
+
+// ContainerListDir returns the entries of a directory of the container, following it when it is a symlink.
+// Running containers list it with ls and stat each entry, for stopped containers the archive of the whole
+// directory has to be read.
+func (d *Docker) ContainerListDir(containerID string, dir string) ([]ContainerFile, error) {
+	resolved, err := d.ContainerResolvePath(containerID, dir)
+	if err != nil {
+		return nil, err
+	}
+	if !resolved.IsDir() {
+		return nil, fmt.Errorf("%s is not a directory", dir)
+	}
+
+	if out, err := d.execOutput(containerID, []string{"ls", "-1A", resolved.Path}); err == nil {
+		names := strings.Split(strings.TrimSuffix(out, "\n"), "\n")
+		return d.statDirEntries(containerID, resolved.Path, dir, names), nil
+	}
+
+	reader, _, err := d.cli.CopyFromContainer(d.ctx, containerID, resolved.Path)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	return listTarDir(reader, dir)
+}
+
+// statDirEntries returns the files named names of the directory resolved, with paths under dir.
+// Entries removed since they were listed are skipped.
+func (d *Docker) statDirEntries(containerID string, resolved string, dir string, names []string) []ContainerFile {
+	stats := make([]*ContainerFile, len(names))
+	limit := make(chan struct{}, 16)
+	var wg sync.WaitGroup
+
+	for i, name := range names {
+		if name == "" {
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int, name string) {
+			defer wg.Done()
+			limit <- struct{}{}
+			defer func() { <-limit }()
+
+			f, err := d.ContainerStatPath(containerID, path.Join(resolved, name))
+			if err != nil {
+				return
+			}
+			f.Name = name
+			f.Path = path.Join(dir, name)
+			stats[i] = &f
+		}(i, name)
+	}
+	wg.Wait()
+
+	files := []ContainerFile{}
+	for _, f := range stats {
+		if f != nil {
+			files = append(files, *f)
+		}
+	}
+	sortFiles(files)
+	return files
+}
+
+// listTarDir reads the archive of a directory returned by CopyFromContainer and keeps its direct children.
+// The archive entries are prefixed with the name of the directory itself.
+func listTarDir(r io.Reader, dir string) ([]ContainerFile, error) {
+	tr := tar.NewReader(r)
+	files := []ContainerFile{}
+	root := ""
+	first := true
+
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		name := strings.TrimSuffix(hdr.Name, "/")
+		if first {
+			root = name
+			first = false
+			continue
+		}
+
+		rel := strings.TrimPrefix(name, root+"/")
+		if rel == name || rel == "" || strings.Contains(rel, "/") {
+			continue
+		}
+
+		files = append(files, ContainerFile{
+			Name:       rel,
+			Path:       path.Join(dir, rel),
+			Size:       hdr.Size,
+			Mode:       hdr.FileInfo().Mode(),
+			ModTime:    hdr.ModTime,
+			LinkTarget: hdr.Linkname,
+		})
+	}
+
+	sortFiles(files)
+	return files, nil
+}
+
+// sortFiles sorts directories first, then by name.
+func sortFiles(files []ContainerFile) {
+	sort.Slice(files, func(i, j int) bool {
+		if files[i].IsDir() != files[j].IsDir() {
+			return files[i].IsDir()
+		}
+		return files[i].Name < files[j].Name
+	})
+}
+
+// CopyFromContainer returns a tar archive of the file or directory at path.
+func (d *Docker) CopyFromContainer(containerID string, p string) (io.ReadCloser, error) {
+	reader, _, err := d.cli.CopyFromContainer(d.ctx, containerID, p)
+	return reader, err
+}
+
+// ReadContainerFile returns at most limit bytes of the file at p and whether the content was truncated.
+func (d *Docker) ReadContainerFile(containerID string, p string, limit int64) ([]byte, bool, error) {
+	reader, err := d.CopyFromContainer(containerID, p)
+	if err != nil {
+		return nil, false, err
+	}
+	defer reader.Close()
+
+	return readTarFile(reader, limit)
+}
+
+func readTarFile(r io.Reader, limit int64) ([]byte, bool, error) {
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil, false, fmt.Errorf("not a regular file")
+		}
+		if err != nil {
+			return nil, false, err
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		content, err := io.ReadAll(io.LimitReader(tr, limit))
+		return content, hdr.Size > limit, err
+	}
+}
+
+// CopyToContainer extracts a tar archive in the directory dir of the container.
+func (d *Docker) CopyToContainer(containerID string, dir string, content io.Reader) error {
+	return d.cli.CopyToContainer(d.ctx, containerID, dir, content, types.CopyToContainerOptions{})
+}
+
+// ExtractTar writes the content of a tar archive in dest, rejecting entries outside of it
+// and entries that go through a symlink, like a symlink to / followed by link/etc/passwd.
+func ExtractTar(r io.Reader, dest string) error {
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		target := filepath.Join(dest, filepath.FromSlash(hdr.Name))
+		rel, err := filepath.Rel(dest, target)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return fmt.Errorf("invalid path in archive: %s", hdr.Name)
+		}
+		if err := checkNoSymlinks(dest, filepath.Dir(rel)); err != nil {
+			return fmt.Errorf("invalid path in archive: %s, %w", hdr.Name, err)
+		}
+		// an existing symlink is replaced instead of followed
+		if info, err := os.Lstat(target); err == nil && info.Mode()&fs.ModeSymlink != 0 {
+			if err := os.Remove(target); err != nil {
+				return err
+			}
+		}
+
+		mode := hdr.FileInfo().Mode()
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, mode.Perm()|0700); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+				return err
+			}
+			f, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode.Perm())
+			if err != nil {
+				return err
+			}
+			_, err = io.Copy(f, tr)
+			f.Close()
+			if err != nil {
+				return err
+			}
+		case tar.TypeSymlink:
+			if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+				return err
+			}
+			os.Remove(target)
+			// symlinks can not be created on some systems, like windows without privileges
+			if err := os.Symlink(hdr.Linkname, target); err != nil && !errors.Is(err, fs.ErrPermission) {
+				return err
+			}
+		}
+	}
+}
+
+// checkNoSymlinks returns an error when a directory of the relative path dir under dest is a symlink.
+func checkNoSymlinks(dest string, dir string) error {
+	current := dest
+	for _, name := range strings.Split(dir, string(filepath.Separator)) {
+		if name == "." || name == "" {
+			continue
+		}
+		current = filepath.Join(current, name)
+		info, err := os.Lstat(current)
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if info.Mode()&fs.ModeSymlink != 0 {
+			return fmt.Errorf("%s is a symlink", current)
+		}
+	}
+	return nil
+}
+
+// WriteTar writes src, a file or directory of the host, as a tar archive with entries relative to its parent directory.
+func WriteTar(w io.Writer, src string) error {
+	tw := tar.NewWriter(w)
+	base := filepath.Dir(src)
+
+	err := filepath.Walk(src, func(p string, info fs.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		link := ""
+		if info.Mode()&fs.ModeSymlink != 0 {
+			if link, err = os.Readlink(p); err != nil {
+				return err
+			}
+		}
+
+		hdr, err := tar.FileInfoHeader(info, link)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(base, p)
+		if err != nil {
+			return err
+		}
+		hdr.Name = filepath.ToSlash(rel)
+		if info.IsDir() {
+			hdr.Name += "/"
+		}
+
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+
+		f, err := os.Open(p)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		_, err = io.Copy(tw, f)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	return tw.Close()
+}
+
+// HostPathSize returns the size of the regular files under p.
+func HostPathSize(p string) int64 {
+	var size int64
+	filepath.Walk(p, func(_ string, info fs.FileInfo, err error) error {
+		if err == nil && info.Mode().IsRegular() {
+			size += info.Size()
+		}
+		return nil
+	})
+	return size
+}
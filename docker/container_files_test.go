@@ -0,0 +1,170 @@
+package docker
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestListTarDir(t *testing.T) {
+	archive := createTar(t, []tarEntry{
+		{name: "etc/", dir: true},
+		{name: "etc/nginx/", dir: true},
+		{name: "etc/nginx/nginx.conf", body: []byte("events {}")},
+		{name: "etc/passwd", body: []byte("root:x:0:0")},
+		{name: "etc/localtime", linkname: "/usr/share/zoneinfo/UTC"},
+	})
+
+	files, err := listTarDir(bytes.NewReader(archive), "/etc")
+	if err != nil {
+		t.Fatalf("listTarDir() error = %v", err)
+	}
+
+	got := []string{}
+	for _, f := range files {
+		got = append(got, f.Path+" "+f.LinkTarget)
+	}
+	want := []string{"/etc/nginx ", "/etc/localtime /usr/share/zoneinfo/UTC", "/etc/passwd "}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("listTarDir() = %v, want %v", got, want)
+	}
+}
+
+func TestReadTarFile(t *testing.T) {
+	tests := []struct {
+		name          string
+		entries       []tarEntry
+		limit         int64
+		wantContent   string
+		wantTruncated bool
+		wantErr       bool
+	}{
+		{
+			name:        "regular file",
+			entries:     []tarEntry{{name: "nginx.conf", body: []byte("events {}")}},
+			limit:       100,
+			wantContent: "events {}",
+		},
+		{
+			name:          "truncated",
+			entries:       []tarEntry{{name: "nginx.conf", body: []byte("events {}")}},
+			limit:         6,
+			wantContent:   "events",
+			wantTruncated: true,
+		},
+		{
+			name:    "directory",
+			entries: []tarEntry{{name: "etc/", dir: true}},
+			limit:   100,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content, truncated, err := readTarFile(bytes.NewReader(createTar(t, tt.entries)), tt.limit)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("readTarFile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if string(content) != tt.wantContent || truncated != tt.wantTruncated {
+				t.Errorf("readTarFile() = %q, %v, want %q, %v", content, truncated, tt.wantContent, tt.wantTruncated)
+			}
+		})
+	}
+}
+
+func TestWriteAndExtractTar(t *testing.T) {
+	src := filepath.Join(t.TempDir(), "app")
+	if err := os.MkdirAll(filepath.Join(src, "config"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(src, "config", "app.yml"), []byte("port: 80"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteTar(&buf, src); err != nil {
+		t.Fatalf("WriteTar() error = %v", err)
+	}
+
+	dest := t.TempDir()
+	if err := ExtractTar(&buf, dest); err != nil {
+		t.Fatalf("ExtractTar() error = %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(dest, "app", "config", "app.yml"))
+	if err != nil || string(content) != "port: 80" {
+		t.Errorf("ExtractTar() content = %q, %v", content, err)
+	}
+}
+
+func TestExtractTarRejectsPathTraversal(t *testing.T) {
+	archive := createTar(t, []tarEntry{{name: "../evil", body: []byte("x")}})
+	if err := ExtractTar(bytes.NewReader(archive), t.TempDir()); err == nil {
+		t.Errorf("ExtractTar() expected error for path outside destination")
+	}
+}
+
+func TestExtractTarRejectsSymlinks(t *testing.T) {
+	tests := []struct {
+		name    string
+		entries func(outside string) []tarEntry
+		wantErr bool
+	}{
+		{
+			name: "should not write through a symlinked directory",
+			entries: func(outside string) []tarEntry {
+				return []tarEntry{
+					{name: "link", linkname: outside},
+					{name: "link/file", body: []byte("x")},
+				}
+			},
+			wantErr: true,
+		},
+		{
+			name: "should not write through a symlink in a subdirectory",
+			entries: func(outside string) []tarEntry {
+				return []tarEntry{
+					{name: "app/", dir: true},
+					{name: "app/link", linkname: "../../" + filepath.Base(outside)},
+					{name: "app/link/file", body: []byte("x")},
+				}
+			},
+			wantErr: true,
+		},
+		{
+			name: "should replace a symlink with a file instead of writing its target",
+			entries: func(outside string) []tarEntry {
+				return []tarEntry{
+					{name: "file", linkname: filepath.Join(outside, "file")},
+					{name: "file", body: []byte("x")},
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			dest := filepath.Join(root, "dest")
+			outside := filepath.Join(root, "outside")
+			for _, dir := range []string{dest, outside} {
+				if err := os.Mkdir(dir, 0755); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			archive := createTar(t, tt.entries(outside))
+			err := ExtractTar(bytes.NewReader(archive), dest)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ExtractTar() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if entries, _ := os.ReadDir(outside); len(entries) > 0 {
+				t.Errorf("ExtractTar() wrote %v outside the destination", entries[0].Name())
+			}
+		})
+	}
+}
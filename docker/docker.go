@@ -5,8 +5,11 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
+	"slices"
 	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/ernesto27/dcli/utils"
@@ -14,6 +17,7 @@ import (
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/api/types/network"
 	"github.com/docker/docker/api/types/volume"
 	"github.com/docker/docker/client"
 )
@@ -22,6 +26,13 @@ type Docker struct {
 	cli *client.Client
 	ctx context.Context
 
+	alerts chan Alert
+	mu     sync.Mutex
+	deaths map[string][]time.Time
+	// failed exits waiting for the restart policy and containers killed by the user, see handleEvent
+	exits  map[string]containerExit
+	killed map[string]bool
+
 	Containers []MyContainer
 	Images     []MyImage
 	Networks   []MyNetwork
@@ -44,18 +55,54 @@ type MyContainer struct {
 	Name         string
 	NameShort    string
 	Image        string
+	ImageID      string
 	ImageShort   string
 	State        string
 	Status       string
 	Ports        []types.Port
 	Size         string
 	SizeOriginal int64
+	SizeRw       int64
 	Command      string
 	Env          []string
-	ReadOnly     bool
-	MountedAt    string
 	Network      MyNetwork
+	Networks     []ContainerEndpoint
 	Mounts       []types.MountPoint
+	Health       *types.Health
+	RestartCount int
+	OOMKilled    bool
+	ExitCode     int
+	FinishedAt   time.Time
+	CrashLoop    bool
+}
+
+// VolumeMounts returns the mounts of the volume in the container, a volume can be mounted in many paths.
+func (c MyContainer) VolumeMounts(name string) []types.MountPoint {
+	mounts := []types.MountPoint{}
+	for _, mount := range c.Mounts {
+		if mount.Type == "volume" && mount.Name == name {
+			mounts = append(mounts, mount)
+		}
+	}
+	return mounts
+}
+
+// HealthStatus returns starting, healthy or unhealthy, or an empty string for containers without a healthcheck.
+func (c MyContainer) HealthStatus() string {
+	if c.Health == nil || c.Health.Status == types.NoHealthcheck {
+		return ""
+	}
+	return c.Health.Status
+}
+
+// ContainerEndpoint is the connection of a container to one network.
+type ContainerEndpoint struct {
+	Name        string
+	IPAddress   string
+	IPv6Address string
+	Gateway     string
+	MacAddress  string
+	Aliases     []string
 }
 
 type MyContainerStats struct {
@@ -96,6 +143,39 @@ func (i *MyImage) GetFormatSize() string {
 	return utils.FormatSize(i.Summary.Size)
 }
 
+// GetID returns the full image ID without the sha256: prefix.
+func (i *MyImage) GetID() string {
+	return strings.TrimPrefix(i.Summary.ID, "sha256:")
+}
+
+func (i *MyImage) GetShortID() string {
+	id := i.GetID()
+	if len(id) > 12 {
+		return id[:12]
+	}
+	return id
+}
+
+// GetTags returns the repository tags of the image, skipping the <none>:<none> placeholder.
+func (i *MyImage) GetTags() []string {
+	tags := []string{}
+	for _, t := range i.Summary.RepoTags {
+		if t != "<none>:<none>" {
+			tags = append(tags, t)
+		}
+	}
+	return tags
+}
+
+// GetName returns the first tag of the image or <none> for untagged images.
+func (i *MyImage) GetName() string {
+	tags := i.GetTags()
+	if len(tags) == 0 {
+		return "<none>"
+	}
+	return tags[0]
+}
+
 func New(ctx context.Context) (*Docker, error) {
 	cli, err := client.NewClientWithOpts(client.FromEnv)
 	if err != nil {
@@ -103,8 +183,12 @@ func New(ctx context.Context) (*Docker, error) {
 	}
 
 	return &Docker{
-		cli: cli,
-		ctx: ctx,
+		cli:    cli,
+		ctx:    ctx,
+		alerts: make(chan Alert, 10),
+		deaths: map[string][]time.Time{},
+		exits:  map[string]containerExit{},
+		killed: map[string]bool{},
 	}, nil
 }
 
@@ -165,12 +249,12 @@ func (d *Docker) ContainerList() ([]MyContainer, error) {
 			gateway = networkSettings.Networks[networkMode].Gateway
 		}
 
-		readOnly := false
-		mountedAt := ""
+		// zero time for containers that never finished
+		finishedAt, _ := time.Parse(time.RFC3339Nano, cJSON.State.FinishedAt)
 
-		if len(c.Mounts) > 0 {
-			readOnly = c.Mounts[0].RW == false
-			mountedAt = c.Mounts[0].Destination
+		var sizeRw int64
+		if cJSON.SizeRw != nil {
+			sizeRw = *cJSON.SizeRw
 		}
 
 		mc = append(mc, MyContainer{
@@ -179,22 +263,29 @@ func (d *Docker) ContainerList() ([]MyContainer, error) {
 			Name:         name,
 			NameShort:    utils.TrimValue(name, 20),
 			Image:        c.Image,
+			ImageID:      c.ImageID,
 			ImageShort:   utils.TrimValue(c.Image, 20),
 			State:        c.State,
 			Status:       c.Status,
 			Ports:        c.Ports,
 			Size:         utils.FormatSize(*cJSON.SizeRootFs),
 			SizeOriginal: *cJSON.SizeRootFs,
+			SizeRw:       sizeRw,
 			Env:          cJSON.Config.Env,
 			Command:      strings.Join(cJSON.Config.Entrypoint, " ") + " " + strings.Join(cJSON.Config.Cmd, " "),
-			ReadOnly:     readOnly,
-			MountedAt:    mountedAt,
 			Network: MyNetwork{
 				Name:      networkMode,
 				IPAddress: ipAddress,
 				Gateway:   gateway,
 			},
-			Mounts: c.Mounts,
+			Networks:     getContainerEndpoints(cJSON),
+			Mounts:       c.Mounts,
+			Health:       cJSON.State.Health,
+			RestartCount: cJSON.RestartCount,
+			OOMKilled:    cJSON.State.OOMKilled,
+			ExitCode:     cJSON.State.ExitCode,
+			FinishedAt:   finishedAt,
+			CrashLoop:    d.isCrashLooping(c.ID, c.State, cJSON.RestartCount),
 		})
 
 		d.Containers = mc
@@ -226,14 +317,31 @@ func (d *Docker) ContainerRemove(containerID string) error {
 	return err
 }
 
-func (d *Docker) ContainerStop(containerID string) error {
-	timeout := 10
+// ContainerStop sends the stop signal and kills the container after timeout seconds.
+func (d *Docker) ContainerStop(containerID string, timeout int) error {
 	err := d.cli.ContainerStop(d.ctx, containerID, container.StopOptions{
 		Timeout: &timeout,
 	})
 	return err
 }
 
+// ContainerStopTimeout returns the stop timeout set when the container was created, 10 seconds by default.
+func (d *Docker) ContainerStopTimeout(containerID string) int {
+	c, err := d.cli.ContainerInspect(d.ctx, containerID)
+	if err != nil || c.Config.StopTimeout == nil {
+		return 10
+	}
+	return *c.Config.StopTimeout
+}
+
+func (d *Docker) ContainerKill(containerID string, signal string) error {
+	return d.cli.ContainerKill(d.ctx, containerID, signal)
+}
+
+func (d *Docker) ContainerRename(containerID string, name string) error {
+	return d.cli.ContainerRename(d.ctx, containerID, name)
+}
+
 func (d *Docker) ContainerStart(containerID string) error {
 	return d.cli.ContainerStart(d.ctx, containerID, types.ContainerStartOptions{})
 }
@@ -254,23 +362,13 @@ func (d *Docker) ImageList() ([]MyImage, error) {
 	images, err := d.cli.ImageList(d.ctx, types.ImageListOptions{})
 	myImages := []MyImage{}
 
-	for index, image := range images {
+	for _, image := range images {
 		imageInspect, _, err := d.cli.ImageInspectWithRaw(d.ctx, image.ID)
 		if err != nil {
 			fmt.Println(err)
 		}
 
-		fullID := strings.Replace(image.ID, "sha256:", "", -1)
-
-		image.ID = utils.TrimValue(fullID, 10)
-		if len(image.RepoTags) > 0 {
-			image.RepoTags[0] = utils.TrimValue(image.RepoTags[0], 40)
-		} else {
-			image.RepoTags = []string{"<none>"}
-		}
-		images[index] = image
-
-		h, err := d.cli.ImageHistory(d.ctx, fullID)
+		h, err := d.cli.ImageHistory(d.ctx, image.ID)
 		if err != nil {
 			fmt.Println(err)
 		}
@@ -290,7 +388,7 @@ func (d *Docker) ImageList() ([]MyImage, error) {
 
 func (d *Docker) GetImageByID(ID string) (MyImage, error) {
 	for _, i := range d.Images {
-		if i.Summary.ID == ID {
+		if i.GetID() == strings.TrimPrefix(ID, "sha256:") {
 			return i, nil
 		}
 	}
@@ -305,6 +403,59 @@ func (d *Docker) ImageRemove(imageID string, force bool) error {
 	return err
 }
 
+func (d *Docker) ImageTag(source string, target string) error {
+	return d.cli.ImageTag(d.ctx, source, target)
+}
+
+func (d *Docker) ImageUntag(tag string) error {
+	_, err := d.cli.ImageRemove(d.ctx, tag, types.ImageRemoveOptions{})
+	return err
+}
+
+func (d *Docker) ImagePush(tag string) (io.ReadCloser, error) {
+	auth, err := getRegistryAuth(tag)
+	if err != nil {
+		return nil, err
+	}
+
+	return d.cli.ImagePush(d.ctx, tag, types.ImagePushOptions{
+		RegistryAuth: auth,
+	})
+}
+
+func (d *Docker) ImageSave(images []string) (io.ReadCloser, error) {
+	return d.cli.ImageSave(d.ctx, images)
+}
+
+func (d *Docker) ImageLoad(input io.Reader) (io.ReadCloser, error) {
+	resp, err := d.cli.ImageLoad(d.ctx, input, false)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// ImageImport creates an image from a tar archive of a filesystem, like the one created by ContainerExport.
+func (d *Docker) ImageImport(input io.Reader, reference string, message string, changes []string) (io.ReadCloser, error) {
+	return d.cli.ImageImport(d.ctx, types.ImageImportSource{Source: input, SourceName: "-"}, reference, types.ImageImportOptions{
+		Message: message,
+		Changes: changes,
+	})
+}
+
+func (d *Docker) GetImagesSize(images []string) int64 {
+	var size int64
+	for _, i := range d.Images {
+		for _, name := range images {
+			if strings.HasPrefix(i.GetID(), strings.TrimPrefix(name, "sha256:")) || slices.Contains(i.GetTags(), name) {
+				size += i.Summary.Size
+				break
+			}
+		}
+	}
+	return size
+}
+
 func (d *Docker) ServerVersion() (string, error) {
 	typesVersion, err := d.cli.ServerVersion(d.ctx)
 	if err != nil {
@@ -318,6 +469,61 @@ func (d *Docker) ContainerRestart(containerID string) error {
 	return d.cli.ContainerRestart(d.ctx, containerID, container.StopOptions{})
 }
 
+// ContainerHealth returns the current health state with the last probes and the healthcheck of the container.
+func (d *Docker) ContainerHealth(containerID string) (*types.Health, *container.HealthConfig, error) {
+	c, err := d.cli.ContainerInspect(d.ctx, containerID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return c.State.Health, c.Config.Healthcheck, nil
+}
+
+func (d *Docker) ContainerHostConfig(containerID string) (*container.HostConfig, error) {
+	c, err := d.cli.ContainerInspect(d.ctx, containerID)
+	if err != nil {
+		return nil, err
+	}
+	return c.HostConfig, nil
+}
+
+// ContainerUpdate changes the resource limits and restart policy of a running or stopped container.
+// Zero values in resources keep the current limits.
+func (d *Docker) ContainerUpdate(containerID string, resources container.Resources, restartPolicy container.RestartPolicy) ([]string, error) {
+	resp, err := d.cli.ContainerUpdate(d.ctx, containerID, container.UpdateConfig{
+		Resources:     resources,
+		RestartPolicy: restartPolicy,
+	})
+	return resp.Warnings, err
+}
+
+// ContainerExport returns the flattened filesystem of the container as a tar archive.
+func (d *Docker) ContainerExport(containerID string) (io.ReadCloser, error) {
+	return d.cli.ContainerExport(d.ctx, containerID)
+}
+
+type CommitOptions struct {
+	Reference string
+	Author    string
+	Message   string
+	Changes   []string
+}
+
+// ContainerCommit creates an image from the container, pausing it while the filesystem is copied.
+func (d *Docker) ContainerCommit(containerID string, options CommitOptions) (string, error) {
+	resp, err := d.cli.ContainerCommit(d.ctx, containerID, types.ContainerCommitOptions{
+		Reference: options.Reference,
+		Author:    options.Author,
+		Comment:   options.Message,
+		Changes:   options.Changes,
+		Pause:     true,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimPrefix(resp.ID, "sha256:"), nil
+}
+
 func (d *Docker) ContainerLogs(containerId string) (string, error) {
 	out, err := d.cli.ContainerLogs(d.ctx, containerId, types.ContainerLogsOptions{
 		ShowStdout: true,
@@ -447,7 +653,7 @@ func (d *Docker) NetworkList() ([]MyNetwork, error) {
 
 		containers := []MyContainer{}
 		for _, c := range d.Containers {
-			if c.Network.Name == network.Name {
+			if c.InNetwork(network.Name) {
 				containers = append(containers, c)
 			}
 		}
@@ -469,6 +675,64 @@ func (d *Docker) NetworkRemove(networkID string) error {
 	return d.cli.NetworkRemove(d.ctx, networkID)
 }
 
+type NetworkCreateOptions struct {
+	Name       string
+	Driver     string
+	Subnet     string
+	Gateway    string
+	IPRange    string
+	Internal   bool
+	Attachable bool
+	Labels     map[string]string
+}
+
+// NetworkCreate creates a network and returns its ID, the IPAM config is only sent when a subnet is set.
+func (d *Docker) NetworkCreate(options NetworkCreateOptions) (string, error) {
+	create := types.NetworkCreate{
+		CheckDuplicate: true,
+		Driver:         options.Driver,
+		Internal:       options.Internal,
+		Attachable:     options.Attachable,
+		Labels:         options.Labels,
+	}
+
+	if options.Subnet != "" {
+		create.IPAM = &network.IPAM{
+			Config: []network.IPAMConfig{{
+				Subnet:  options.Subnet,
+				Gateway: options.Gateway,
+				IPRange: options.IPRange,
+			}},
+		}
+	}
+
+	resp, err := d.cli.NetworkCreate(d.ctx, options.Name, create)
+	if err != nil {
+		return "", err
+	}
+	if resp.Warning != "" {
+		fmt.Println(resp.Warning)
+	}
+	return resp.ID, nil
+}
+
+// NetworkConnect connects the container to the network, ip is optional and can be an IPv4 or IPv6 address.
+func (d *Docker) NetworkConnect(networkID string, containerID string, aliases []string, ip string) error {
+	settings := &network.EndpointSettings{Aliases: aliases}
+	if ip != "" {
+		settings.IPAMConfig = &network.EndpointIPAMConfig{IPv4Address: ip}
+		if strings.Contains(ip, ":") {
+			settings.IPAMConfig = &network.EndpointIPAMConfig{IPv6Address: ip}
+		}
+	}
+
+	return d.cli.NetworkConnect(d.ctx, networkID, containerID, settings)
+}
+
+func (d *Docker) NetworkDisconnect(networkID string, containerID string, force bool) error {
+	return d.cli.NetworkDisconnect(d.ctx, networkID, containerID, force)
+}
+
 func (d *Docker) getContainerIP(c types.ContainerJSON) string {
 	networkSettings := c.NetworkSettings
 	networkMode := string(c.HostConfig.NetworkMode)
@@ -481,6 +745,42 @@ func (d *Docker) getContainerIP(c types.ContainerJSON) string {
 	return ipAddress
 }
 
+func getContainerEndpoints(c types.ContainerJSON) []ContainerEndpoint {
+	endpoints := []ContainerEndpoint{}
+	if c.NetworkSettings == nil {
+		return endpoints
+	}
+
+	for name, e := range c.NetworkSettings.Networks {
+		if e == nil {
+			continue
+		}
+		endpoints = append(endpoints, ContainerEndpoint{
+			Name:        name,
+			IPAddress:   e.IPAddress,
+			IPv6Address: e.GlobalIPv6Address,
+			Gateway:     e.Gateway,
+			MacAddress:  e.MacAddress,
+			Aliases:     e.Aliases,
+		})
+	}
+
+	sort.Slice(endpoints, func(i, j int) bool {
+		return endpoints[i].Name < endpoints[j].Name
+	})
+	return endpoints
+}
+
+// InNetwork reports whether the container is connected to the network.
+func (c MyContainer) InNetwork(name string) bool {
+	for _, e := range c.Networks {
+		if e.Name == name {
+			return true
+		}
+	}
+	return c.Network.Name == name
+}
+
 func (d *Docker) GetNetworkByName(name string) (MyNetwork, error) {
 	for _, n := range d.Networks {
 		if n.Resource.Name == name {
@@ -503,10 +803,8 @@ func (d *Docker) VolumeList() ([]MyVolume, error) {
 
 		containers := []MyContainer{}
 		for _, c := range d.Containers {
-			for _, mount := range c.Mounts {
-				if mount.Type == "volume" && mount.Name == name {
-					containers = append(containers, c)
-				}
+			if len(c.VolumeMounts(name)) > 0 {
+				containers = append(containers, c)
 			}
 		}
 
@@ -570,7 +868,14 @@ func (d *Docker) Events() {
 			case err := <-err:
 				fmt.Println(err)
 
-			case <-eventStream:
+			case event := <-eventStream:
+				if alert, ok := d.handleEvent(event); ok {
+					select {
+					case d.alerts <- alert:
+					default:
+						// nobody is reading the alerts
+					}
+				}
 				d.ContainerList()
 			}
 		}
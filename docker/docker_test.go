@@ -104,3 +104,44 @@ func TestGetContainerName(t *testing.T) {
 		})
 	}
 }
+
+func TestGetImageName(t *testing.T) {
+	tests := []struct {
+		name      string
+		summary   types.ImageSummary
+		want      string
+		wantShort string
+	}{
+		{
+			name: "should get first tag and short id",
+			summary: types.ImageSummary{
+				ID:       "sha256:6a59f1cbb8d28ac484176d52c473494859a512ddba3ea62a547258cf16c9b3ae",
+				RepoTags: []string{"registry.example.com/team/a-very-long-application-name:1.0.0", "app:latest"},
+			},
+			want:      "registry.example.com/team/a-very-long-application-name:1.0.0",
+			wantShort: "6a59f1cbb8d2",
+		},
+		{
+			name: "should get none for untagged images",
+			summary: types.ImageSummary{
+				ID:          "sha256:1234",
+				RepoTags:    []string{"<none>:<none>"},
+				RepoDigests: []string{"nginx@sha256:abcd"},
+			},
+			want:      "<none>",
+			wantShort: "1234",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i := MyImage{Summary: tt.summary}
+			if got := i.GetName(); got != tt.want {
+				t.Errorf("GetName() = got %v, want %v", got, tt.want)
+			}
+			if got := i.GetShortID(); got != tt.wantShort {
+				t.Errorf("GetShortID() = got %v, want %v", got, tt.wantShort)
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package docker
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/docker/docker/api/types/events"
+)
+
+const (
+	// a container restarted after crashing crashLoopRestarts times in crashLoopWindow is crash looping
+	crashLoopRestarts = 3
+	crashLoopWindow   = 5 * time.Minute
+)
+
+type containerExit struct {
+	at   time.Time
+	code string
+}
+
+type Alert struct {
+	ContainerID string
+	Container   string
+	Text        string
+}
+
+// Alerts returns the notifications about containers killed by OOM or crash looping, raised from the event stream.
+func (d *Docker) Alerts() <-chan Alert {
+	return d.alerts
+}
+
+// handleEvent records when containers crash and returns an alert for OOM kills and crash loops.
+// A crash is an exit with a non-zero code that the restart policy restarted, the daemon sends a start
+// event after the die. Containers stopped, killed or restarted by the user get a kill event first.
+func (d *Docker) handleEvent(event events.Message) (Alert, bool) {
+	if event.Type != events.ContainerEventType {
+		return Alert{}, false
+	}
+
+	id := event.Actor.ID
+	name := event.Actor.Attributes["name"]
+	alert := Alert{ContainerID: id, Container: name}
+
+	switch event.Action {
+	case "oom":
+		alert.Text = fmt.Sprintf("Container %s was killed because it ran out of memory", name)
+		return alert, true
+	case "kill", "die", "start":
+		d.mu.Lock()
+		defer d.mu.Unlock()
+
+		if d.deaths == nil {
+			d.deaths = map[string][]time.Time{}
+			d.exits = map[string]containerExit{}
+			d.killed = map[string]bool{}
+		}
+		now := time.Unix(0, event.TimeNano)
+
+		switch event.Action {
+		case "kill":
+			d.killed[id] = true
+			return Alert{}, false
+		case "die":
+			code := event.Actor.Attributes["exitCode"]
+			if code != "0" && !d.killed[id] {
+				d.exits[id] = containerExit{at: now, code: code}
+			}
+			delete(d.killed, id)
+			return Alert{}, false
+		}
+
+		exit, ok := d.exits[id]
+		if !ok {
+			return Alert{}, false
+		}
+		delete(d.exits, id)
+
+		deaths := recentDeaths(append(d.deaths[id], exit.at), now)
+		d.deaths[id] = deaths
+
+		// alert once, when the container starts crash looping
+		if len(deaths) == crashLoopRestarts {
+			alert.Text = fmt.Sprintf("Container %s is crash looping, it exited %d times in the last %s (last exit code %s)",
+				name, len(deaths), crashLoopWindow, exit.code)
+			return alert, true
+		}
+	}
+
+	return Alert{}, false
+}
+
+func recentDeaths(deaths []time.Time, now time.Time) []time.Time {
+	recent := []time.Time{}
+	for _, t := range deaths {
+		if now.Sub(t) <= crashLoopWindow {
+			recent = append(recent, t)
+		}
+	}
+	return recent
+}
+
+func (d *Docker) isCrashLooping(containerID string, state string, restartCount int) bool {
+	if state == "restarting" && restartCount >= crashLoopRestarts {
+		return true
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return len(recentDeaths(d.deaths[containerID], time.Now())) >= crashLoopRestarts
+}
@@ -0,0 +1,115 @@
+package docker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types/events"
+)
+
+func containerEvent(action string, at time.Time) events.Message {
+	return events.Message{
+		Type:     events.ContainerEventType,
+		Action:   action,
+		Actor:    events.Actor{ID: "abc", Attributes: map[string]string{"name": "web", "exitCode": "1"}},
+		TimeNano: at.UnixNano(),
+	}
+}
+
+// restarted returns the events of a container that exited with code and was started again, after a kill when manual is set.
+func restarted(code string, manual bool, at time.Time) []events.Message {
+	die := containerEvent("die", at)
+	die.Actor.Attributes["exitCode"] = code
+
+	messages := []events.Message{die, containerEvent("start", at)}
+	if manual {
+		messages = append([]events.Message{containerEvent("kill", at)}, messages...)
+	}
+	return messages
+}
+
+func join(messages ...[]events.Message) []events.Message {
+	joined := []events.Message{}
+	for _, m := range messages {
+		joined = append(joined, m...)
+	}
+	return joined
+}
+
+func TestHandleEvent(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name      string
+		events    []events.Message
+		wantAlert bool
+	}{
+		{
+			name:      "should alert when a container is killed by oom",
+			events:    []events.Message{containerEvent("oom", now)},
+			wantAlert: true,
+		},
+		{
+			name:   "should not alert for a single exit",
+			events: restarted("1", false, now),
+		},
+		{
+			name: "should alert when a container is restarted three times in the window",
+			events: join(
+				restarted("1", false, now.Add(-2*time.Minute)),
+				restarted("1", false, now.Add(-time.Minute)),
+				restarted("1", false, now),
+			),
+			wantAlert: true,
+		},
+		{
+			name: "should not alert for restarts outside the window",
+			events: join(
+				restarted("1", false, now.Add(-20*time.Minute)),
+				restarted("1", false, now.Add(-10*time.Minute)),
+				restarted("1", false, now),
+			),
+		},
+		{
+			name: "should not alert for exits that are not restarted",
+			events: []events.Message{
+				containerEvent("die", now.Add(-2*time.Minute)),
+				containerEvent("die", now.Add(-time.Minute)),
+				containerEvent("die", now),
+			},
+		},
+		{
+			name: "should not alert for exits with code 0",
+			events: join(
+				restarted("0", false, now.Add(-2*time.Minute)),
+				restarted("0", false, now.Add(-time.Minute)),
+				restarted("0", false, now),
+			),
+		},
+		{
+			name: "should not alert for containers stopped and restarted by the user",
+			events: join(
+				restarted("143", true, now.Add(-2*time.Minute)),
+				restarted("137", true, now.Add(-time.Minute)),
+				restarted("143", true, now),
+			),
+		},
+		{
+			name:   "should ignore events of other types",
+			events: []events.Message{{Type: events.ImageEventType, Action: "oom"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &Docker{}
+			alert := false
+			for _, e := range tt.events {
+				_, alert = d.handleEvent(e)
+			}
+			if alert != tt.wantAlert {
+				t.Errorf("handleEvent() = %v, want %v", alert, tt.wantAlert)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package docker
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/pkg/stdcopy"
+)
+
+// shells are probed in order of preference.
+var shells = []string{"/bin/bash", "/bin/zsh", "/bin/ash", "/bin/sh", "/busybox/sh"}
+
+// ContainerShells returns the shells that can be executed in a running container.
+func (d *Docker) ContainerShells(containerID string) []string {
+	found := []string{}
+	for _, shell := range shells {
+		if d.execSucceeds(containerID, []string{shell, "-c", "exit 0"}) {
+			found = append(found, shell)
+		}
+	}
+	return found
+}
+
+func (d *Docker) execSucceeds(containerID string, cmd []string) bool {
+	resp, err := d.cli.ContainerExecCreate(d.ctx, containerID, types.ExecConfig{
+		Cmd:          cmd,
+		AttachStdout: true,
+		AttachStderr: true,
+	})
+	if err != nil {
+		return false
+	}
+
+	hijacked, err := d.cli.ContainerExecAttach(d.ctx, resp.ID, types.ExecStartCheck{})
+	if err != nil {
+		return false
+	}
+	defer hijacked.Close()
+
+	if _, err := io.Copy(io.Discard, hijacked.Reader); err != nil {
+		return false
+	}
+
+	inspect, err := d.cli.ContainerExecInspect(d.ctx, resp.ID)
+	return err == nil && !inspect.Running && inspect.ExitCode == 0
+}
+
+// execOutput runs cmd in a running container and returns its output, an exit code other than 0 is an error.
+func (d *Docker) execOutput(containerID string, cmd []string) (string, error) {
+	resp, err := d.cli.ContainerExecCreate(d.ctx, containerID, types.ExecConfig{
+		Cmd:          cmd,
+		AttachStdout: true,
+		AttachStderr: true,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	hijacked, err := d.cli.ContainerExecAttach(d.ctx, resp.ID, types.ExecStartCheck{})
+	if err != nil {
+		return "", err
+	}
+	defer hijacked.Close()
+
+	var stdout, stderr bytes.Buffer
+	if _, err := stdcopy.StdCopy(&stdout, &stderr, hijacked.Reader); err != nil {
+		return "", err
+	}
+
+	// the exec can still be reported as running right after its output is closed
+	for i := 0; i < 10; i++ {
+		inspect, err := d.cli.ContainerExecInspect(d.ctx, resp.ID)
+		if err != nil {
+			return "", err
+		}
+		if !inspect.Running {
+			if inspect.ExitCode != 0 {
+				return "", fmt.Errorf("%s exited with code %d: %s", strings.Join(cmd, " "), inspect.ExitCode, strings.TrimSpace(stderr.String()))
+			}
+			return stdout.String(), nil
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	return "", fmt.Errorf("%s is still running", strings.Join(cmd, " "))
+}
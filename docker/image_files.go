@@ -0,0 +1,286 @@
+package docker
+
+import (
+	"archive/tar"
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+)
+
+type FileChange string
+
+const (
+	FileAdded    FileChange = "A"
+	FileModified FileChange = "M"
+	FileDeleted  FileChange = "D"
+)
+
+const (
+	whiteoutPrefix = ".wh."
+	whiteoutOpaque = ".wh..wh..opq"
+)
+
+type LayerFile struct {
+	Path   string
+	Size   int64
+	Mode   fs.FileMode
+	IsDir  bool
+	Change FileChange
+}
+
+type MyLayerFiles struct {
+	Layer string
+	Files []LayerFile
+}
+
+type WastedFile struct {
+	Path  string
+	Size  int64
+	Count int
+}
+
+type MyImageFiles struct {
+	Layers     []MyLayerFiles
+	Wasted     []WastedFile
+	WastedSize int64
+}
+
+type archiveManifest struct {
+	Layers []string
+}
+
+// ImageFiles exports the image and returns the files added, modified or deleted by every layer.
+func (d *Docker) ImageFiles(imageID string) (MyImageFiles, error) {
+	body, err := d.cli.ImageSave(d.ctx, []string{imageID})
+	if err != nil {
+		return MyImageFiles{}, err
+	}
+	defer body.Close()
+
+	return ParseImageArchive(body)
+}
+
+// ReadImageArchive walks a docker save archive calling fn with the content of
+// every layer and returns the layer names in manifest order, base layer first.
+// Entries that are not tar archives, like the image config, are skipped. An error of fn is returned.
+func ReadImageArchive(r io.Reader, fn func(name string, layer *tar.Reader) error) ([]string, error) {
+	tr := tar.NewReader(r)
+	links := map[string]string{}
+	manifest := []archiveManifest{}
+
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch hdr.Typeflag {
+		case tar.TypeSymlink:
+			links[hdr.Name] = path.Join(path.Dir(hdr.Name), hdr.Linkname)
+		case tar.TypeReg:
+			if hdr.Name == "manifest.json" {
+				if err := json.NewDecoder(tr).Decode(&manifest); err != nil {
+					return nil, err
+				}
+				continue
+			}
+
+			layer, closeLayer, err := newLayerReader(tr)
+			if err != nil {
+				return nil, fmt.Errorf("layer %s: %w", hdr.Name, err)
+			}
+			if layer == nil {
+				continue
+			}
+			err = fn(hdr.Name, layer)
+			closeLayer()
+			if err != nil {
+				return nil, fmt.Errorf("layer %s: %w", hdr.Name, err)
+			}
+		}
+	}
+
+	layers := []string{}
+	if len(manifest) > 0 {
+		for _, name := range manifest[0].Layers {
+			if link, ok := links[name]; ok {
+				name = link
+			}
+			layers = append(layers, name)
+		}
+	}
+
+	return layers, nil
+}
+
+// ParseImageArchive reads a docker save archive and resolves the changes of each layer in manifest order.
+func ParseImageArchive(r io.Reader) (MyImageFiles, error) {
+	layerFiles := map[string][]LayerFile{}
+
+	layers, err := ReadImageArchive(r, func(name string, layer *tar.Reader) error {
+		files, err := readLayerFiles(layer)
+		if err == nil {
+			layerFiles[name] = files
+		}
+		return err
+	})
+	if err != nil {
+		return MyImageFiles{}, err
+	}
+
+	raw := [][]LayerFile{}
+	for _, name := range layers {
+		raw = append(raw, layerFiles[name])
+	}
+
+	imageFiles := resolveLayerChanges(raw)
+	for i, name := range layers {
+		imageFiles.Layers[i].Layer = name
+	}
+
+	return imageFiles, nil
+}
+
+// newLayerReader returns a nil reader when r is neither a gzip nor a tar archive.
+func newLayerReader(r io.Reader) (*tar.Reader, func(), error) {
+	br := bufio.NewReader(r)
+	if magic, err := br.Peek(2); err == nil && magic[0] == 0x1f && magic[1] == 0x8b {
+		gz, err := gzip.NewReader(br)
+		if err != nil {
+			return nil, nil, err
+		}
+		return tar.NewReader(gz), func() { gz.Close() }, nil
+	}
+
+	// tar headers have the "ustar" magic at offset 257
+	if header, err := br.Peek(262); err != nil || string(header[257:]) != "ustar" {
+		return nil, nil, nil
+	}
+
+	return tar.NewReader(br), func() {}, nil
+}
+
+func readLayerFiles(tr *tar.Reader) ([]LayerFile, error) {
+	files := []LayerFile{}
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return files, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		name := CleanLayerPath(hdr.Name)
+		if name == "" {
+			continue
+		}
+
+		files = append(files, LayerFile{
+			Path:  name,
+			Size:  hdr.Size,
+			Mode:  hdr.FileInfo().Mode(),
+			IsDir: hdr.Typeflag == tar.TypeDir,
+		})
+	}
+}
+
+// CleanLayerPath normalizes a path inside a layer tar to the form "usr/bin/env".
+func CleanLayerPath(name string) string {
+	return strings.TrimPrefix(path.Clean("/"+name), "/")
+}
+
+// resolveLayerChanges marks every file as added, modified or deleted and
+// accounts the space wasted by files that later layers overwrite or remove.
+func resolveLayerChanges(layers [][]LayerFile) MyImageFiles {
+	result := MyImageFiles{}
+	current := map[string]LayerFile{}
+	wasted := map[string]*WastedFile{}
+
+	waste := func(f LayerFile) {
+		if f.IsDir || f.Size == 0 {
+			return
+		}
+		if _, ok := wasted[f.Path]; !ok {
+			wasted[f.Path] = &WastedFile{Path: f.Path}
+		}
+		wasted[f.Path].Size += f.Size
+		wasted[f.Path].Count++
+	}
+
+	remove := func(target string) {
+		for p, f := range current {
+			if p == target || strings.HasPrefix(p, target+"/") {
+				waste(f)
+				delete(current, p)
+			}
+		}
+	}
+
+	for _, files := range layers {
+		// opaque directories hide the lower layers only, so they are applied before the entries of the layer
+		for _, f := range files {
+			if dir, base := path.Split(f.Path); base == whiteoutOpaque {
+				for p, prev := range current {
+					if strings.HasPrefix(p, dir) {
+						waste(prev)
+						delete(current, p)
+					}
+				}
+			}
+		}
+
+		changes := []LayerFile{}
+		for _, f := range files {
+			dir, base := path.Split(f.Path)
+
+			if base == whiteoutOpaque {
+				continue
+			}
+
+			if strings.HasPrefix(base, whiteoutPrefix) {
+				target := dir + strings.TrimPrefix(base, whiteoutPrefix)
+				prev, existed := current[target]
+				remove(target)
+				changes = append(changes, LayerFile{Path: target, IsDir: existed && prev.IsDir, Change: FileDeleted})
+				continue
+			}
+
+			if prev, ok := current[f.Path]; ok {
+				if !f.IsDir {
+					waste(prev)
+					f.Change = FileModified
+				}
+			} else {
+				f.Change = FileAdded
+			}
+
+			current[f.Path] = f
+			changes = append(changes, f)
+		}
+
+		result.Layers = append(result.Layers, MyLayerFiles{Files: changes})
+	}
+
+	for _, w := range wasted {
+		result.Wasted = append(result.Wasted, *w)
+		result.WastedSize += w.Size
+	}
+	sort.Slice(result.Wasted, func(i, j int) bool {
+		if result.Wasted[i].Size == result.Wasted[j].Size {
+			return result.Wasted[i].Path < result.Wasted[j].Path
+		}
+		return result.Wasted[i].Size > result.Wasted[j].Size
+	})
+
+	return result
+}
@@ -0,0 +1,129 @@
+package docker
+
+import (
+	"archive/tar"
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+type tarEntry struct {
+	name     string
+	body     []byte
+	dir      bool
+	linkname string
+}
+
+func createTar(t *testing.T, entries []tarEntry) []byte {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for _, e := range entries {
+		hdr := &tar.Header{Name: e.name, Mode: 0644, Size: int64(len(e.body)), Typeflag: tar.TypeReg}
+		if e.dir {
+			hdr.Typeflag = tar.TypeDir
+			hdr.Mode = 0755
+		}
+		if e.linkname != "" {
+			hdr.Typeflag = tar.TypeSymlink
+			hdr.Linkname = e.linkname
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write(e.body); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestParseImageArchive(t *testing.T) {
+	layer1 := createTar(t, []tarEntry{
+		{name: "app/", dir: true},
+		{name: "app/config.json", body: []byte("0123456789")},
+		{name: "app/cache/", dir: true},
+		{name: "app/cache/data.bin", body: []byte("01234")},
+		{name: "tmp/", dir: true},
+		{name: "tmp/build.log", body: []byte("log")},
+	})
+	layer2 := createTar(t, []tarEntry{
+		{name: "app/", dir: true},
+		{name: "app/config.json", body: []byte("{}")},
+		{name: "app/.wh.cache"},
+		{name: "tmp/", dir: true},
+		{name: "tmp/new.log", body: []byte("new")},
+		{name: "tmp/.wh..wh..opq"},
+		{name: "app/main", body: []byte("binary")},
+	})
+
+	archive := createTar(t, []tarEntry{
+		{name: "layer1/layer.tar", body: layer1},
+		{name: "layer2/layer.tar", body: layer2},
+		{name: "layer3/layer.tar", linkname: "../layer1/layer.tar"},
+		{name: "config.json", body: []byte(`{"architecture":"amd64"}`)},
+		{name: "manifest.json", body: []byte(`[{"Layers":["layer1/layer.tar","layer2/layer.tar","layer3/layer.tar"]}]`)},
+	})
+
+	got, err := ParseImageArchive(bytes.NewReader(archive))
+	if err != nil {
+		t.Fatalf("ParseImageArchive() error = %v", err)
+	}
+
+	if len(got.Layers) != 3 {
+		t.Fatalf("ParseImageArchive() got %d layers, want 3", len(got.Layers))
+	}
+
+	changes := func(files []LayerFile) map[string]FileChange {
+		c := map[string]FileChange{}
+		for _, f := range files {
+			c[f.Path] = f.Change
+		}
+		return c
+	}
+
+	wantLayer2 := map[string]FileChange{
+		"app":             "",
+		"app/config.json": FileModified,
+		"app/cache":       FileDeleted,
+		"tmp":             "",
+		"tmp/new.log":     FileAdded,
+		"app/main":        FileAdded,
+	}
+	if got := changes(got.Layers[1].Files); !reflect.DeepEqual(got, wantLayer2) {
+		t.Errorf("ParseImageArchive() layer 2 changes = %v, want %v", got, wantLayer2)
+	}
+
+	if got := changes(got.Layers[2].Files); got["app/cache/data.bin"] != FileAdded || got["app/config.json"] != FileModified {
+		t.Errorf("ParseImageArchive() symlinked layer changes = %v", got)
+	}
+
+	wantWasted := []WastedFile{
+		{Path: "app/config.json", Size: 12, Count: 2},
+		{Path: "app/cache/data.bin", Size: 5, Count: 1},
+		{Path: "tmp/build.log", Size: 3, Count: 1},
+	}
+	if !reflect.DeepEqual(got.Wasted, wantWasted) {
+		t.Errorf("ParseImageArchive() wasted = %v, want %v", got.Wasted, wantWasted)
+	}
+	if got.WastedSize != 20 {
+		t.Errorf("ParseImageArchive() wasted size = %d, want 20", got.WastedSize)
+	}
+}
+
+func TestParseImageArchiveCorruptLayer(t *testing.T) {
+	layer := createTar(t, []tarEntry{
+		{name: "app/config.json", body: bytes.Repeat([]byte("0"), 2048)},
+	})
+
+	archive := createTar(t, []tarEntry{
+		{name: "layer1/layer.tar", body: layer[:1024]},
+		{name: "manifest.json", body: []byte(`[{"Layers":["layer1/layer.tar"]}]`)},
+	})
+
+	if _, err := ParseImageArchive(bytes.NewReader(archive)); err == nil {
+		t.Errorf("ParseImageArchive() error = nil, want the error of the truncated layer")
+	}
+}
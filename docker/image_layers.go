@@ -0,0 +1,95 @@
+package docker
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/docker/docker/api/types/image"
+)
+
+type MyImageLayer struct {
+	ID             string
+	DiffID         string
+	Created        int64
+	CreatedBy      string
+	Comment        string
+	Size           int64
+	CumulativeSize int64
+	Largest        bool
+}
+
+// instructions that only change the image config and never create a filesystem layer
+var emptyLayerInstructions = []string{
+	"ARG", "CMD", "ENTRYPOINT", "ENV", "EXPOSE", "HEALTHCHECK", "LABEL",
+	"MAINTAINER", "ONBUILD", "SHELL", "STOPSIGNAL", "USER", "VOLUME",
+}
+
+const largestLayersCount = 3
+
+func isEmptyLayer(item image.HistoryResponseItem) bool {
+	if item.Size > 0 {
+		return false
+	}
+
+	createdBy := strings.TrimSpace(item.CreatedBy)
+	if createdBy == "" {
+		return true
+	}
+
+	if strings.Contains(createdBy, "#(nop)") {
+		return !strings.Contains(createdBy, "ADD ") && !strings.Contains(createdBy, "COPY ")
+	}
+
+	for _, instruction := range emptyLayerInstructions {
+		if strings.HasPrefix(createdBy, instruction+" ") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// GetLayers returns the image history from the base layer up, matching every
+// entry that creates a filesystem layer with its diff id in RootFS.Layers.
+func (i *MyImage) GetLayers() []MyImageLayer {
+	layers := []MyImageLayer{}
+	diffIDs := i.Inspect.RootFS.Layers
+
+	var cumulative int64
+	for _, h := range i.History {
+		cumulative += h.Size
+
+		layer := MyImageLayer{
+			ID:             h.ID,
+			Created:        h.Created,
+			CreatedBy:      h.CreatedBy,
+			Comment:        h.Comment,
+			Size:           h.Size,
+			CumulativeSize: cumulative,
+		}
+
+		if !isEmptyLayer(h) && len(diffIDs) > 0 {
+			layer.DiffID = diffIDs[0]
+			diffIDs = diffIDs[1:]
+		}
+
+		layers = append(layers, layer)
+	}
+
+	bySize := make([]int, len(layers))
+	for index := range layers {
+		bySize[index] = index
+	}
+	sort.SliceStable(bySize, func(a, b int) bool {
+		return layers[bySize[a]].Size > layers[bySize[b]].Size
+	})
+
+	for n, index := range bySize {
+		if n >= largestLayersCount || layers[index].Size == 0 {
+			break
+		}
+		layers[index].Largest = true
+	}
+
+	return layers
+}
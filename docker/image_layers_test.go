@@ -0,0 +1,57 @@
+package docker
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/image"
+)
+
+func TestGetLayers(t *testing.T) {
+	img := MyImage{
+		Inspect: types.ImageInspect{
+			RootFS: types.RootFS{
+				Layers: []string{"sha256:base", "sha256:run", "sha256:copy", "sha256:workdir"},
+			},
+		},
+		History: []image.HistoryResponseItem{
+			{ID: "<missing>", CreatedBy: "/bin/sh -c #(nop) ADD file:123 in / ", Size: 100},
+			{ID: "<missing>", CreatedBy: "/bin/sh -c #(nop)  CMD [\"bash\"]", Size: 0},
+			{ID: "<missing>", CreatedBy: "RUN /bin/sh -c apt-get update # buildkit", Size: 300},
+			{ID: "<missing>", CreatedBy: "ENV PATH=/usr/local/bin", Size: 0},
+			{ID: "<missing>", CreatedBy: "COPY . /app # buildkit", Size: 50},
+			{ID: "<missing>", CreatedBy: "WORKDIR /app", Size: 0},
+			{ID: "sha256:abc", CreatedBy: "EXPOSE map[80/tcp:{}]", Size: 0},
+		},
+	}
+
+	layers := img.GetLayers()
+	if len(layers) != len(img.History) {
+		t.Fatalf("GetLayers() got %d layers, want %d", len(layers), len(img.History))
+	}
+
+	diffIDs := []string{}
+	cumulative := []int64{}
+	largest := []bool{}
+	for _, l := range layers {
+		diffIDs = append(diffIDs, l.DiffID)
+		cumulative = append(cumulative, l.CumulativeSize)
+		largest = append(largest, l.Largest)
+	}
+
+	wantDiffIDs := []string{"sha256:base", "", "sha256:run", "", "sha256:copy", "sha256:workdir", ""}
+	if !reflect.DeepEqual(diffIDs, wantDiffIDs) {
+		t.Errorf("GetLayers() diff ids = %v, want %v", diffIDs, wantDiffIDs)
+	}
+
+	wantCumulative := []int64{100, 100, 400, 400, 450, 450, 450}
+	if !reflect.DeepEqual(cumulative, wantCumulative) {
+		t.Errorf("GetLayers() cumulative sizes = %v, want %v", cumulative, wantCumulative)
+	}
+
+	wantLargest := []bool{true, false, true, false, true, false, false}
+	if !reflect.DeepEqual(largest, wantLargest) {
+		t.Errorf("GetLayers() largest = %v, want %v", largest, wantLargest)
+	}
+}
@@ -0,0 +1,157 @@
+package docker
+
+import (
+	"sort"
+)
+
+type MyImageRelations struct {
+	Containers   []MyContainer
+	Parents      []MyImage
+	Children     []MyImage
+	SharedLayers []MyImage
+}
+
+type MyImageNode struct {
+	Image    MyImage
+	Children []*MyImageNode
+}
+
+func isLayerPrefix(base []string, layers []string) bool {
+	if len(base) == 0 || len(base) >= len(layers) {
+		return false
+	}
+
+	for i := range base {
+		if base[i] != layers[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// GetImageParent returns the image used as base, either the parent recorded by the
+// builder or the local image with the longest layer chain that prefixes this one.
+func GetImageParent(image MyImage, images []MyImage) (MyImage, bool) {
+	if image.Inspect.Parent != "" {
+		for _, i := range images {
+			if i.Inspect.ID == image.Inspect.Parent {
+				return i, true
+			}
+		}
+	}
+
+	parent := MyImage{}
+	found := false
+	for _, i := range images {
+		if i.Inspect.ID == image.Inspect.ID {
+			continue
+		}
+
+		if isLayerPrefix(i.Inspect.RootFS.Layers, image.Inspect.RootFS.Layers) &&
+			(!found || len(i.Inspect.RootFS.Layers) > len(parent.Inspect.RootFS.Layers)) {
+			parent = i
+			found = true
+		}
+	}
+
+	return parent, found
+}
+
+func (d *Docker) GetImageRelations(image MyImage) MyImageRelations {
+	return getImageRelations(image, d.Images, d.Containers)
+}
+
+func getImageRelations(image MyImage, images []MyImage, containers []MyContainer) MyImageRelations {
+	relations := MyImageRelations{}
+
+	for _, c := range containers {
+		if c.ImageID != "" && c.ImageID == image.Inspect.ID {
+			relations.Containers = append(relations.Containers, c)
+		}
+	}
+
+	related := map[string]bool{image.Inspect.ID: true}
+
+	current := image
+	for {
+		parent, ok := GetImageParent(current, images)
+		if !ok || related[parent.Inspect.ID] {
+			break
+		}
+		related[parent.Inspect.ID] = true
+		relations.Parents = append(relations.Parents, parent)
+		current = parent
+	}
+
+	for _, i := range images {
+		if related[i.Inspect.ID] {
+			continue
+		}
+
+		if parent, ok := GetImageParent(i, images); ok && parent.Inspect.ID == image.Inspect.ID {
+			relations.Children = append(relations.Children, i)
+			related[i.Inspect.ID] = true
+		}
+	}
+
+	for _, i := range images {
+		if related[i.Inspect.ID] {
+			continue
+		}
+
+		layers := i.Inspect.RootFS.Layers
+		if len(layers) > 0 && len(image.Inspect.RootFS.Layers) > 0 && layers[0] == image.Inspect.RootFS.Layers[0] {
+			relations.SharedLayers = append(relations.SharedLayers, i)
+		}
+	}
+
+	return relations
+}
+
+// CountSharedLayers returns how many base layers both images have in common.
+func CountSharedLayers(a MyImage, b MyImage) int {
+	count := 0
+	for count < len(a.Inspect.RootFS.Layers) && count < len(b.Inspect.RootFS.Layers) &&
+		a.Inspect.RootFS.Layers[count] == b.Inspect.RootFS.Layers[count] {
+		count++
+	}
+	return count
+}
+
+// BuildImageTree groups the local images under the image they were built from.
+func BuildImageTree(images []MyImage) []*MyImageNode {
+	nodes := map[string]*MyImageNode{}
+	for _, i := range images {
+		nodes[i.Inspect.ID] = &MyImageNode{Image: i}
+	}
+
+	roots := []*MyImageNode{}
+	for _, i := range images {
+		node := nodes[i.Inspect.ID]
+		if parent, ok := GetImageParent(i, images); ok && nodes[parent.Inspect.ID] != node {
+			nodes[parent.Inspect.ID].Children = append(nodes[parent.Inspect.ID].Children, node)
+		} else {
+			roots = append(roots, node)
+		}
+	}
+
+	var sortNodes func(list []*MyImageNode)
+	sortNodes = func(list []*MyImageNode) {
+		sort.Slice(list, func(a, b int) bool {
+			return imageName(list[a].Image) < imageName(list[b].Image)
+		})
+		for _, n := range list {
+			sortNodes(n.Children)
+		}
+	}
+	sortNodes(roots)
+
+	return roots
+}
+
+func imageName(image MyImage) string {
+	if len(image.Inspect.RepoTags) > 0 {
+		return image.Inspect.RepoTags[0]
+	}
+	return image.Inspect.ID
+}
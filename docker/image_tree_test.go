@@ -0,0 +1,68 @@
+package docker
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types"
+)
+
+func newTestImage(id string, tag string, layers ...string) MyImage {
+	return MyImage{
+		Summary: types.ImageSummary{ID: id, RepoTags: []string{tag}},
+		Inspect: types.ImageInspect{
+			ID:       "sha256:" + id,
+			RepoTags: []string{tag},
+			RootFS:   types.RootFS{Layers: layers},
+		},
+	}
+}
+
+func TestGetImageRelations(t *testing.T) {
+	base := newTestImage("base", "debian:12", "l1")
+	app := newTestImage("app", "app:1", "l1", "l2")
+	appDev := newTestImage("appdev", "app:dev", "l1", "l2", "l3")
+	other := newTestImage("other", "other:1", "l1", "l9")
+	unrelated := newTestImage("alpine", "alpine:3", "x1")
+	images := []MyImage{base, app, appDev, other, unrelated}
+
+	containers := []MyContainer{
+		{Name: "web", ImageID: "sha256:app"},
+		{Name: "db", ImageID: "sha256:alpine"},
+	}
+
+	got := getImageRelations(app, images, containers)
+
+	if len(got.Containers) != 1 || got.Containers[0].Name != "web" {
+		t.Errorf("getImageRelations() containers = %v, want [web]", got.Containers)
+	}
+	if len(got.Parents) != 1 || got.Parents[0].Summary.ID != "base" {
+		t.Errorf("getImageRelations() parents = %v, want [base]", got.Parents)
+	}
+	if len(got.Children) != 1 || got.Children[0].Summary.ID != "appdev" {
+		t.Errorf("getImageRelations() children = %v, want [appdev]", got.Children)
+	}
+	if len(got.SharedLayers) != 1 || got.SharedLayers[0].Summary.ID != "other" {
+		t.Errorf("getImageRelations() shared layers = %v, want [other]", got.SharedLayers)
+	}
+}
+
+func TestBuildImageTree(t *testing.T) {
+	base := newTestImage("base", "debian:12", "l1")
+	app := newTestImage("app", "app:1", "l1", "l2")
+	appDev := newTestImage("appdev", "app:dev", "l1", "l2", "l3")
+	unrelated := newTestImage("alpine", "alpine:3", "x1")
+
+	roots := BuildImageTree([]MyImage{appDev, app, unrelated, base})
+
+	if len(roots) != 2 || roots[0].Image.Summary.ID != "alpine" || roots[1].Image.Summary.ID != "base" {
+		t.Fatalf("BuildImageTree() roots = %v, want [alpine base]", roots)
+	}
+
+	children := roots[1].Children
+	if len(children) != 1 || children[0].Image.Summary.ID != "app" {
+		t.Fatalf("BuildImageTree() base children = %v, want [app]", children)
+	}
+	if len(children[0].Children) != 1 || children[0].Children[0].Image.Summary.ID != "appdev" {
+		t.Errorf("BuildImageTree() app children = %v, want [appdev]", children[0].Children)
+	}
+}
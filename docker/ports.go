@@ -0,0 +1,117 @@
+package docker
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+)
+
+// FormatPorts formats the ports of a container like docker ps, merging consecutive ports in ranges,
+// for example 0.0.0.0:8000-8002->8000-8002/tcp, [::]:8080->80/tcp or 53/udp for ports not published.
+func FormatPorts(ports []types.Port) []string {
+	sorted := []types.Port{}
+	seen := map[types.Port]bool{}
+	for _, p := range ports {
+		if !seen[p] {
+			seen[p] = true
+			sorted = append(sorted, p)
+		}
+	}
+
+	sort.Slice(sorted, func(i, j int) bool {
+		a, b := sorted[i], sorted[j]
+		if a.Type != b.Type {
+			return a.Type < b.Type
+		}
+		if a.IP != b.IP {
+			return a.IP < b.IP
+		}
+		if a.PrivatePort != b.PrivatePort {
+			return a.PrivatePort < b.PrivatePort
+		}
+		return a.PublicPort < b.PublicPort
+	})
+
+	result := []string{}
+	for i := 0; i < len(sorted); {
+		start := sorted[i]
+		end := start
+		j := i + 1
+		for ; j < len(sorted); j++ {
+			next := sorted[j]
+			consecutive := next.Type == start.Type && next.IP == start.IP && next.PrivatePort == end.PrivatePort+1 &&
+				((start.PublicPort == 0 && next.PublicPort == 0) || (start.PublicPort != 0 && next.PublicPort == end.PublicPort+1))
+			if !consecutive {
+				break
+			}
+			end = next
+		}
+
+		result = append(result, formatPortRange(start, end))
+		i = j
+	}
+
+	return result
+}
+
+func formatPortRange(start types.Port, end types.Port) string {
+	private := portRange(start.PrivatePort, end.PrivatePort)
+	if start.PublicPort == 0 {
+		return private + "/" + start.Type
+	}
+
+	ip := start.IP
+	if strings.Contains(ip, ":") {
+		ip = "[" + ip + "]"
+	}
+	return fmt.Sprintf("%s:%s->%s/%s", ip, portRange(start.PublicPort, end.PublicPort), private, start.Type)
+}
+
+func portRange(start uint16, end uint16) string {
+	if start == end {
+		return fmt.Sprintf("%d", start)
+	}
+	return fmt.Sprintf("%d-%d", start, end)
+}
+
+// PublishedPorts returns the host ports of the container without duplicates, the tcp ports first.
+func PublishedPorts(ports []types.Port) []types.Port {
+	published := []types.Port{}
+	seen := map[string]bool{}
+	for _, p := range ports {
+		key := fmt.Sprintf("%d/%s", p.PublicPort, p.Type)
+		if p.PublicPort == 0 || seen[key] {
+			continue
+		}
+		seen[key] = true
+		published = append(published, p)
+	}
+
+	sort.SliceStable(published, func(i, j int) bool {
+		if published[i].Type != published[j].Type {
+			return published[i].Type == "tcp"
+		}
+		return published[i].PublicPort < published[j].PublicPort
+	})
+
+	return published
+}
+
+// PublishedURLs returns the urls of the tcp ports published on the host, https for the ports 443 and 8443.
+func PublishedURLs(ports []types.Port) []string {
+	urls := []string{}
+	for _, p := range PublishedPorts(ports) {
+		if p.Type != "tcp" {
+			continue
+		}
+
+		scheme := "http"
+		if p.PrivatePort == 443 || p.PrivatePort == 8443 {
+			scheme = "https"
+		}
+		urls = append(urls, fmt.Sprintf("%s://localhost:%d", scheme, p.PublicPort))
+	}
+	return urls
+}
@@ -0,0 +1,85 @@
+package docker
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+)
+
+func TestFormatPorts(t *testing.T) {
+	tests := []struct {
+		name  string
+		ports []types.Port
+		want  []string
+	}{
+		{
+			name: "should format ipv4 and ipv6 bindings",
+			ports: []types.Port{
+				{IP: "0.0.0.0", PrivatePort: 80, PublicPort: 8080, Type: "tcp"},
+				{IP: "::", PrivatePort: 80, PublicPort: 8080, Type: "tcp"},
+			},
+			want: []string{"0.0.0.0:8080->80/tcp", "[::]:8080->80/tcp"},
+		},
+		{
+			name: "should merge consecutive ports in ranges",
+			ports: []types.Port{
+				{IP: "0.0.0.0", PrivatePort: 8001, PublicPort: 9001, Type: "tcp"},
+				{IP: "0.0.0.0", PrivatePort: 8000, PublicPort: 9000, Type: "tcp"},
+				{IP: "0.0.0.0", PrivatePort: 8002, PublicPort: 9002, Type: "tcp"},
+				{IP: "0.0.0.0", PrivatePort: 8005, PublicPort: 9005, Type: "tcp"},
+			},
+			want: []string{"0.0.0.0:9000-9002->8000-8002/tcp", "0.0.0.0:9005->8005/tcp"},
+		},
+		{
+			name: "should format ports not published and udp",
+			ports: []types.Port{
+				{PrivatePort: 53, Type: "udp"},
+				{PrivatePort: 6379, Type: "tcp"},
+				{PrivatePort: 6380, Type: "tcp"},
+			},
+			want: []string{"6379-6380/tcp", "53/udp"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FormatPorts(tt.ports); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FormatPorts() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPublishedPorts(t *testing.T) {
+	ports := []types.Port{
+		{PrivatePort: 53, PublicPort: 5353, Type: "udp"},
+		{IP: "0.0.0.0", PrivatePort: 443, PublicPort: 8443, Type: "tcp"},
+		{IP: "::", PrivatePort: 443, PublicPort: 8443, Type: "tcp"},
+		{PrivatePort: 9000, Type: "tcp"},
+		{IP: "0.0.0.0", PrivatePort: 80, PublicPort: 8080, Type: "tcp"},
+	}
+
+	got := []uint16{}
+	for _, p := range PublishedPorts(ports) {
+		got = append(got, p.PublicPort)
+	}
+	want := []uint16{8080, 8443, 5353}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("PublishedPorts() = %v, want %v", got, want)
+	}
+}
+
+func TestPublishedURLs(t *testing.T) {
+	ports := []types.Port{
+		{PrivatePort: 53, PublicPort: 5353, Type: "udp"},
+		{IP: "0.0.0.0", PrivatePort: 443, PublicPort: 9443, Type: "tcp"},
+		{IP: "::", PrivatePort: 443, PublicPort: 9443, Type: "tcp"},
+		{IP: "0.0.0.0", PrivatePort: 80, PublicPort: 8080, Type: "tcp"},
+	}
+
+	want := []string{"http://localhost:8080", "https://localhost:9443"}
+	if got := PublishedURLs(ports); !reflect.DeepEqual(got, want) {
+		t.Errorf("PublishedURLs() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,155 @@
+package docker
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/docker/distribution/reference"
+	"github.com/docker/docker/api/types/registry"
+)
+
+const dockerHubAuthKey = "https://index.docker.io/v1/"
+
+// dockerConfig holds the parts of the docker cli config.json used to
+// authenticate against registries and attach to containers.
+type dockerConfig struct {
+	Auths       map[string]registry.AuthConfig `json:"auths"`
+	CredsStore  string                         `json:"credsStore"`
+	CredHelpers map[string]string              `json:"credHelpers"`
+	DetachKeys  string                         `json:"detachKeys"`
+}
+
+type credentialHelperResponse struct {
+	ServerURL string
+	Username  string
+	Secret    string
+}
+
+func loadDockerConfig() (dockerConfig, error) {
+	config := dockerConfig{}
+
+	dir := os.Getenv("DOCKER_CONFIG")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return config, err
+		}
+		dir = filepath.Join(home, ".docker")
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "config.json"))
+	if errors.Is(err, os.ErrNotExist) {
+		return config, nil
+	}
+	if err != nil {
+		return config, err
+	}
+
+	err = json.Unmarshal(data, &config)
+	return config, err
+}
+
+// getRegistryAuthKey returns the key used in config.json for the registry of an image reference.
+func getRegistryAuthKey(image string) (string, error) {
+	named, err := reference.ParseNormalizedNamed(image)
+	if err != nil {
+		return "", err
+	}
+
+	domain := reference.Domain(named)
+	if domain == "docker.io" {
+		return dockerHubAuthKey, nil
+	}
+	return domain, nil
+}
+
+func IsDockerHubImage(image string) bool {
+	key, err := getRegistryAuthKey(image)
+	return err == nil && key == dockerHubAuthKey
+}
+
+// getAuthConfig resolves the credentials for key, first from a credential helper and then from the auths section.
+func getAuthConfig(config dockerConfig, key string) (registry.AuthConfig, error) {
+	helper := config.CredsStore
+	if h, ok := config.CredHelpers[key]; ok {
+		helper = h
+	}
+
+	if helper != "" {
+		auth, err := getAuthFromHelper(helper, key)
+		if err == nil {
+			return auth, nil
+		}
+	}
+
+	for k, auth := range config.Auths {
+		if k != key && strings.TrimPrefix(strings.TrimPrefix(k, "https://"), "http://") != key {
+			continue
+		}
+
+		if auth.Auth != "" && auth.Username == "" {
+			decoded, err := base64.StdEncoding.DecodeString(auth.Auth)
+			if err != nil {
+				return registry.AuthConfig{}, err
+			}
+			username, password, _ := strings.Cut(string(decoded), ":")
+			auth.Username = username
+			auth.Password = password
+			auth.Auth = ""
+		}
+		auth.ServerAddress = key
+		return auth, nil
+	}
+
+	return registry.AuthConfig{ServerAddress: key}, nil
+}
+
+func getAuthFromHelper(helper string, key string) (registry.AuthConfig, error) {
+	cmd := exec.Command("docker-credential-"+helper, "get")
+	cmd.Stdin = strings.NewReader(key)
+
+	out, err := cmd.Output()
+	if err != nil {
+		return registry.AuthConfig{}, err
+	}
+
+	var resp credentialHelperResponse
+	if err := json.NewDecoder(bytes.NewReader(out)).Decode(&resp); err != nil {
+		return registry.AuthConfig{}, err
+	}
+
+	auth := registry.AuthConfig{ServerAddress: key}
+	if resp.Username == "<token>" {
+		auth.IdentityToken = resp.Secret
+	} else {
+		auth.Username = resp.Username
+		auth.Password = resp.Secret
+	}
+	return auth, nil
+}
+
+// getRegistryAuth returns the encoded X-Registry-Auth header for image using the docker cli config.
+func getRegistryAuth(image string) (string, error) {
+	key, err := getRegistryAuthKey(image)
+	if err != nil {
+		return "", err
+	}
+
+	config, err := loadDockerConfig()
+	if err != nil {
+		return "", err
+	}
+
+	auth, err := getAuthConfig(config, key)
+	if err != nil {
+		return "", err
+	}
+
+	return registry.EncodeAuthConfig(auth)
+}
@@ -0,0 +1,92 @@
+package docker
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types/registry"
+)
+
+func TestGetRegistryAuthKey(t *testing.T) {
+	tests := []struct {
+		name  string
+		image string
+		want  string
+	}{
+		{
+			name:  "should get docker hub key for official images",
+			image: "nginx:latest",
+			want:  dockerHubAuthKey,
+		},
+		{
+			name:  "should get docker hub key for user images",
+			image: "ernesto27/dcli:1.0",
+			want:  dockerHubAuthKey,
+		},
+		{
+			name:  "should get registry domain for private registries",
+			image: "registry.example.com:5000/app:1.0",
+			want:  "registry.example.com:5000",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := getRegistryAuthKey(tt.image)
+			if err != nil {
+				t.Fatalf("getRegistryAuthKey() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("getRegistryAuthKey() = got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetAuthConfig(t *testing.T) {
+	config := dockerConfig{
+		Auths: map[string]registry.AuthConfig{
+			dockerHubAuthKey: {
+				// user:secret
+				Auth: "dXNlcjpzZWNyZXQ=",
+			},
+			"https://registry.example.com": {
+				Username: "admin",
+				Password: "admin",
+			},
+		},
+	}
+
+	tests := []struct {
+		name string
+		key  string
+		want registry.AuthConfig
+	}{
+		{
+			name: "should decode auth field",
+			key:  dockerHubAuthKey,
+			want: registry.AuthConfig{Username: "user", Password: "secret", ServerAddress: dockerHubAuthKey},
+		},
+		{
+			name: "should match keys saved with scheme",
+			key:  "registry.example.com",
+			want: registry.AuthConfig{Username: "admin", Password: "admin", ServerAddress: "registry.example.com"},
+		},
+		{
+			name: "should get empty credentials for unknown registries",
+			key:  "unknown.example.com",
+			want: registry.AuthConfig{ServerAddress: "unknown.example.com"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := getAuthConfig(config, tt.key)
+			if err != nil {
+				t.Fatalf("getAuthConfig() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("getAuthConfig() = got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,224 @@
+package docker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/moby/term"
+	"github.com/muesli/cancelreader"
+)
+
+const defaultDetachKeys = "ctrl-p,ctrl-q"
+
+// Terminal connects the current terminal to a process of a container through the API.
+// It satisfies the bubbletea ExecCommand interface so it can be run with tea.Exec.
+type Terminal struct {
+	tty        bool
+	detachKeys string
+	start      func(detachKeys string) (types.HijackedResponse, error)
+	resize     func(height uint, width uint) error
+	exitCode   func() (int, error)
+
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+}
+
+func (t *Terminal) SetStdin(r io.Reader) {
+	t.stdin = r
+}
+
+func (t *Terminal) SetStdout(w io.Writer) {
+	t.stdout = w
+}
+
+func (t *Terminal) SetStderr(w io.Writer) {
+	t.stderr = w
+}
+
+// Run streams the process stdio until it exits or the detach keys are pressed.
+func (t *Terminal) Run() error {
+	escapeKeys, err := term.ToBytes(t.detachKeys)
+	if err != nil {
+		return fmt.Errorf("invalid detach keys %s: %w", t.detachKeys, err)
+	}
+
+	resp, err := t.start(t.detachKeys)
+	if err != nil {
+		return err
+	}
+	defer resp.Close()
+
+	if t.tty {
+		if fd, isTerminal := term.GetFdInfo(t.stdin); isTerminal {
+			state, err := term.SetRawTerminal(fd)
+			if err != nil {
+				return err
+			}
+			defer term.RestoreTerminal(fd, state)
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		if fd, isTerminal := term.GetFdInfo(t.stdout); isTerminal {
+			t.resizeTo(fd)
+			go monitorResize(ctx, fd, t.resizeTo)
+		}
+	}
+
+	input, err := cancelreader.NewReader(t.stdin)
+	if err != nil {
+		return err
+	}
+	// the reader is cancelled so it does not steal keys from the ui once the session ends
+	defer input.Cancel()
+
+	detached := make(chan struct{})
+	go func() {
+		_, err := io.Copy(resp.Conn, term.NewEscapeProxy(input, escapeKeys))
+		if errors.As(err, &term.EscapeError{}) {
+			close(detached)
+			return
+		}
+		resp.CloseWrite()
+	}()
+
+	output := make(chan error, 1)
+	go func() {
+		var err error
+		if t.tty {
+			_, err = io.Copy(t.stdout, resp.Reader)
+		} else {
+			_, err = stdcopy.StdCopy(t.stdout, t.stderr, resp.Reader)
+		}
+		output <- err
+	}()
+
+	select {
+	case <-detached:
+		return nil
+	case err := <-output:
+		if err != nil && !errors.Is(err, io.EOF) {
+			return err
+		}
+	}
+
+	code, err := t.exitCode()
+	if err != nil {
+		return err
+	}
+	if code != 0 {
+		return fmt.Errorf("process exited with code %d", code)
+	}
+	return nil
+}
+
+func (t *Terminal) resizeTo(fd uintptr) {
+	size, err := term.GetWinsize(fd)
+	if err != nil || size.Height == 0 || size.Width == 0 {
+		return
+	}
+	t.resize(uint(size.Height), uint(size.Width))
+}
+
+// ExecOptions configures the process started by ContainerExec.
+type ExecOptions struct {
+	Cmd        []string
+	User       string
+	WorkingDir string
+	Env        []string
+}
+
+// ContainerExec returns a terminal session that starts a new process with a tty in the container.
+func (d *Docker) ContainerExec(containerID string, options ExecOptions) *Terminal {
+	execID := ""
+
+	return &Terminal{
+		tty:        true,
+		detachKeys: d.DetachKeys(),
+		start: func(detachKeys string) (types.HijackedResponse, error) {
+			resp, err := d.cli.ContainerExecCreate(d.ctx, containerID, types.ExecConfig{
+				Cmd:          options.Cmd,
+				User:         options.User,
+				WorkingDir:   options.WorkingDir,
+				Env:          options.Env,
+				Tty:          true,
+				AttachStdin:  true,
+				AttachStdout: true,
+				AttachStderr: true,
+				DetachKeys:   detachKeys,
+			})
+			if err != nil {
+				return types.HijackedResponse{}, err
+			}
+
+			execID = resp.ID
+			return d.cli.ContainerExecAttach(d.ctx, execID, types.ExecStartCheck{Tty: true})
+		},
+		resize: func(height uint, width uint) error {
+			return d.cli.ContainerExecResize(d.ctx, execID, types.ResizeOptions{Height: height, Width: width})
+		},
+		exitCode: func() (int, error) {
+			// the exec can still be reported as running right after its output is closed
+			for i := 0; i < 10; i++ {
+				inspect, err := d.cli.ContainerExecInspect(d.ctx, execID)
+				if err != nil {
+					return 0, err
+				}
+				if !inspect.Running {
+					return inspect.ExitCode, nil
+				}
+				time.Sleep(50 * time.Millisecond)
+			}
+			return 0, nil
+		},
+	}
+}
+
+// AttachStream is a connection to the stdio of the main process of a container.
+type AttachStream struct {
+	types.HijackedResponse
+	Tty   bool
+	Stdin bool
+}
+
+// ContainerAttachStream connects to the main process of a running container, like docker attach only the new output is received.
+func (d *Docker) ContainerAttachStream(containerID string) (AttachStream, error) {
+	c, err := d.cli.ContainerInspect(d.ctx, containerID)
+	if err != nil {
+		return AttachStream{}, err
+	}
+	if !c.State.Running {
+		return AttachStream{}, fmt.Errorf("container %s is not running", c.Name)
+	}
+
+	resp, err := d.cli.ContainerAttach(d.ctx, containerID, types.ContainerAttachOptions{
+		Stream: true,
+		Stdin:  c.Config.OpenStdin,
+		Stdout: true,
+		Stderr: true,
+	})
+	if err != nil {
+		return AttachStream{}, err
+	}
+
+	return AttachStream{HijackedResponse: resp, Tty: c.Config.Tty, Stdin: c.Config.OpenStdin}, nil
+}
+
+func (d *Docker) ContainerResize(containerID string, height uint, width uint) error {
+	return d.cli.ContainerResize(d.ctx, containerID, types.ResizeOptions{Height: height, Width: width})
+}
+
+// DetachKeys returns the detach sequence of the docker cli config, ctrl-p,ctrl-q by default.
+func (d *Docker) DetachKeys() string {
+	config, err := loadDockerConfig()
+	if err != nil || config.DetachKeys == "" {
+		return defaultDetachKeys
+	}
+	return config.DetachKeys
+}
@@ -0,0 +1,25 @@
+//go:build !windows
+
+package docker
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+func monitorResize(ctx context.Context, fd uintptr, resize func(fd uintptr)) {
+	sigchan := make(chan os.Signal, 1)
+	signal.Notify(sigchan, syscall.SIGWINCH)
+	defer signal.Stop(sigchan)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-sigchan:
+			resize(fd)
+		}
+	}
+}
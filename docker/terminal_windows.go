@@ -0,0 +1,34 @@
+//go:build windows
+
+package docker
+
+import (
+	"context"
+	"time"
+
+	"github.com/moby/term"
+)
+
+// monitorResize polls the console size, windows has no signal for resize events.
+func monitorResize(ctx context.Context, fd uintptr, resize func(fd uintptr)) {
+	var height, width uint16
+	if size, err := term.GetWinsize(fd); err == nil {
+		height, width = size.Height, size.Width
+	}
+
+	ticker := time.NewTicker(250 * time.Millisecond)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			size, err := term.GetWinsize(fd)
+			if err == nil && (size.Height != height || size.Width != width) {
+				height, width = size.Height, size.Width
+				resize(fd)
+			}
+		}
+	}
+}
@@ -0,0 +1,238 @@
+package models
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/ernesto27/dcli/docker"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/moby/term"
+)
+
+const maxAttachLines = 1000
+
+type attachOutputMsg struct {
+	updates <-chan string
+	text    string
+	done    bool
+}
+
+type attachWriter struct {
+	updates chan<- string
+}
+
+func (w attachWriter) Write(p []byte) (int, error) {
+	w.updates <- string(p)
+	return len(p), nil
+}
+
+type ContainerAttach struct {
+	viewport     viewport.Model
+	containerID  string
+	container    string
+	stream       docker.AttachStream
+	updates      <-chan string
+	output       string
+	detachKeys   string
+	escapeKeys   []byte
+	pending      []byte
+	closed       bool
+	MessageError string
+}
+
+func NewContainerAttach(dockerClient *docker.Docker, containerID string, container string) (ContainerAttach, tea.Cmd, error) {
+	detachKeys := dockerClient.DetachKeys()
+	escapeKeys, err := term.ToBytes(detachKeys)
+	if err != nil {
+		return ContainerAttach{}, nil, fmt.Errorf("invalid detach keys %s: %w", detachKeys, err)
+	}
+
+	stream, err := dockerClient.ContainerAttachStream(containerID)
+	if err != nil {
+		return ContainerAttach{}, nil, err
+	}
+
+	vp := viewport.New(140, 25)
+	vp.Style = lipgloss.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("62")).
+		PaddingRight(2)
+
+	if stream.Tty {
+		dockerClient.ContainerResize(containerID, uint(vp.Height), uint(vp.Width))
+	}
+
+	updates := make(chan string)
+	go func() {
+		defer close(updates)
+		w := attachWriter{updates: updates}
+		if stream.Tty {
+			io.Copy(w, stream.Reader)
+		} else {
+			stdcopy.StdCopy(w, w, stream.Reader)
+		}
+	}()
+
+	ca := ContainerAttach{
+		viewport:    vp,
+		containerID: containerID,
+		container:   container,
+		stream:      stream,
+		updates:     updates,
+		detachKeys:  detachKeys,
+		escapeKeys:  escapeKeys,
+	}
+	if !stream.Stdin {
+		ca.MessageError = "The container was started without stdin open (-i), only the output is shown"
+	}
+
+	return ca, waitUpdates(ca.updates), nil
+}
+
+func waitUpdates(updates <-chan string) tea.Cmd {
+	return func() tea.Msg {
+		text, ok := <-updates
+		return attachOutputMsg{updates: updates, text: text, done: !ok}
+	}
+}
+
+func (ca ContainerAttach) View() string {
+	title := titleTableStyle("ATTACH " + ca.container)
+	status := ""
+	if ca.closed {
+		status = "\n  The container process closed the stream"
+	}
+	help := helpStyle(fmt.Sprintf("\n  Keys are sent to the container, esc too • %s: detach\n", ca.detachKeys))
+
+	return title + "\n" + ca.viewport.View() + status + "\n" + ca.MessageError + help
+}
+
+func (ca ContainerAttach) Update(msg tea.Msg, m *model) (ContainerAttach, tea.Cmd) {
+	if msg, ok := msg.(attachOutputMsg); ok {
+		if msg.updates != ca.updates {
+			// output of a previous session, keep draining it until it closes
+			if !msg.done {
+				return ca, waitUpdates(msg.updates)
+			}
+			return ca, nil
+		}
+		if msg.done {
+			ca.closed = true
+			return ca, nil
+		}
+
+		ca.appendOutput(msg.text)
+		return ca, waitUpdates(ca.updates)
+	}
+
+	if m.currentModel != MContainerAttach {
+		// the view was left, closing the connection does not stop the container
+		if !ca.closed && ca.updates != nil {
+			ca.detach()
+		}
+		return ca, nil
+	}
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		forward, detached := ca.matchDetachKeys(keyBytes(msg, ca.stream.Tty))
+		if detached {
+			ca.detach()
+			m.setContainerList()
+			return ca, tea.ClearScreen
+		}
+
+		if len(forward) > 0 && ca.stream.Stdin && !ca.closed {
+			if _, err := ca.stream.Conn.Write(forward); err != nil {
+				ca.MessageError = err.Error()
+			}
+		}
+	}
+
+	return ca, nil
+}
+
+func (ca *ContainerAttach) detach() {
+	ca.stream.Close()
+	ca.closed = true
+}
+
+func (ca *ContainerAttach) appendOutput(text string) {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	text = strings.ReplaceAll(text, "\r", "")
+	ca.output += text
+
+	lines := strings.Split(ca.output, "\n")
+	if len(lines) > maxAttachLines {
+		ca.output = strings.Join(lines[len(lines)-maxAttachLines:], "\n")
+	}
+
+	ca.viewport.SetContent(ca.output)
+	ca.viewport.GotoBottom()
+}
+
+// matchDetachKeys holds back the keys that can be part of the detach sequence,
+// returning the bytes to send to the container and whether the sequence was completed.
+func (ca *ContainerAttach) matchDetachKeys(input []byte) ([]byte, bool) {
+	forward := []byte{}
+	for _, b := range input {
+		if len(ca.escapeKeys) > 0 && ca.escapeKeys[len(ca.pending)] == b {
+			ca.pending = append(ca.pending, b)
+			if bytes.Equal(ca.pending, ca.escapeKeys) {
+				ca.pending = nil
+				return nil, true
+			}
+			continue
+		}
+
+		forward = append(forward, ca.pending...)
+		forward = append(forward, b)
+		ca.pending = nil
+	}
+	return forward, false
+}
+
+// keyBytes translates a key press to the bytes a terminal would send.
+func keyBytes(msg tea.KeyMsg, tty bool) []byte {
+	var b []byte
+	switch msg.Type {
+	case tea.KeyRunes:
+		b = []byte(string(msg.Runes))
+	case tea.KeySpace:
+		b = []byte(" ")
+	case tea.KeyEnter:
+		b = []byte("\n")
+		if tty {
+			b = []byte("\r")
+		}
+	case tea.KeyUp:
+		b = []byte("\x1b[A")
+	case tea.KeyDown:
+		b = []byte("\x1b[B")
+	case tea.KeyRight:
+		b = []byte("\x1b[C")
+	case tea.KeyLeft:
+		b = []byte("\x1b[D")
+	case tea.KeyHome:
+		b = []byte("\x1b[H")
+	case tea.KeyEnd:
+		b = []byte("\x1b[F")
+	case tea.KeyDelete:
+		b = []byte("\x1b[3~")
+	default:
+		if (msg.Type >= 0 && msg.Type < 32) || msg.Type == 127 {
+			b = []byte{byte(msg.Type)}
+		}
+	}
+
+	if msg.Alt && len(b) > 0 {
+		b = append([]byte{0x1b}, b...)
+	}
+	return b
+}
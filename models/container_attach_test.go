@@ -0,0 +1,107 @@
+package models
+
+import (
+	"net"
+	"reflect"
+	"testing"
+
+	"github.com/ernesto27/dcli/docker"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/docker/docker/api/types"
+)
+
+func TestMatchDetachKeys(t *testing.T) {
+	tests := []struct {
+		name         string
+		input        [][]byte
+		want         []byte
+		wantDetached bool
+	}{
+		{
+			name:  "should forward keys not in the sequence",
+			input: [][]byte{[]byte("ls"), {'\r'}},
+			want:  []byte("ls\r"),
+		},
+		{
+			name:         "should detach with ctrl+p ctrl+q",
+			input:        [][]byte{{16}, {17}},
+			want:         []byte{},
+			wantDetached: true,
+		},
+		{
+			name:  "should forward held keys when the sequence is broken",
+			input: [][]byte{{16}, []byte("a")},
+			want:  []byte{16, 'a'},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ca := ContainerAttach{escapeKeys: []byte{16, 17}}
+			got := []byte{}
+			detached := false
+			for _, in := range tt.input {
+				forward, d := ca.matchDetachKeys(in)
+				got = append(got, forward...)
+				detached = detached || d
+			}
+
+			if !reflect.DeepEqual(got, tt.want) || detached != tt.wantDetached {
+				t.Errorf("matchDetachKeys() = got %v %v, want %v %v", got, detached, tt.want, tt.wantDetached)
+			}
+		})
+	}
+}
+
+func TestKeyBytes(t *testing.T) {
+	tests := []struct {
+		name string
+		key  tea.KeyMsg
+		tty  bool
+		want []byte
+	}{
+		{name: "runes", key: tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("ñ")}, want: []byte("ñ")},
+		{name: "enter tty", key: tea.KeyMsg{Type: tea.KeyEnter}, tty: true, want: []byte("\r")},
+		{name: "enter without tty", key: tea.KeyMsg{Type: tea.KeyEnter}, want: []byte("\n")},
+		{name: "ctrl+c", key: tea.KeyMsg{Type: tea.KeyCtrlC}, want: []byte{3}},
+		{name: "alt+b", key: tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("b"), Alt: true}, want: []byte("\x1bb")},
+		{name: "arrow up", key: tea.KeyMsg{Type: tea.KeyUp}, want: []byte("\x1b[A")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := keyBytes(tt.key, tt.tty); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("keyBytes() = got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestContainerAttachEsc(t *testing.T) {
+	client, server := net.Pipe()
+	defer client.Close()
+	defer server.Close()
+
+	received := make(chan []byte)
+	go func() {
+		buf := make([]byte, 8)
+		n, _ := server.Read(buf)
+		received <- buf[:n]
+	}()
+
+	m := model{currentModel: MContainerAttach}
+	m.containerAttach = ContainerAttach{
+		stream:     docker.AttachStream{HijackedResponse: types.HijackedResponse{Conn: client}, Stdin: true},
+		updates:    make(chan string),
+		escapeKeys: []byte{16, 17},
+	}
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if got := updated.(model).currentModel; got != MContainerAttach {
+		t.Errorf("Update() currentModel = %v, want %v", got, MContainerAttach)
+	}
+	if got := <-received; !reflect.DeepEqual(got, []byte{27}) {
+		t.Errorf("Update() sent %v to the container, want esc", got)
+	}
+}
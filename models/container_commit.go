@@ -0,0 +1,157 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ernesto27/dcli/docker"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// commitInstructions are the Dockerfile instructions accepted by docker commit --change.
+var commitInstructions = []string{"CMD", "ENTRYPOINT", "ENV", "EXPOSE", "LABEL", "ONBUILD", "USER", "VOLUME", "WORKDIR", "STOPSIGNAL", "HEALTHCHECK"}
+
+type ContainerCommit struct {
+	Form
+	containerID string
+	container   string
+}
+
+func NewContainerCommit(containerID string, container string) ContainerCommit {
+	return ContainerCommit{
+		Form: NewForm([]FormField{
+			{Label: "Repository:tag", Placeholder: "myapp:debug"},
+			{Label: "Author (optional)", Placeholder: "Jane Doe <jane@example.com>"},
+			{Label: "Message (optional)", Placeholder: "Installed debugging tools"},
+			{Label: "Changes (Dockerfile instructions separated by ;, optional)", Placeholder: `CMD ["nginx", "-g", "daemon off;"]; ENV DEBUG=1; EXPOSE 8080`},
+		}),
+		containerID: containerID,
+		container:   container,
+	}
+}
+
+func (cc ContainerCommit) View() string {
+	title := fmt.Sprintf("Commit container: %s", cc.container)
+	return cc.Form.View(title)
+}
+
+func (cc ContainerCommit) Update(msg tea.Msg, m *model) (ContainerCommit, tea.Cmd) {
+	if m.currentModel != MContainerCommit {
+		return cc, nil
+	}
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "enter":
+			reference := cc.Value(0)
+			if reference == "" {
+				cc.MessageError = "repository:tag is required"
+				return cc, nil
+			}
+
+			changes, err := parseCommitChanges(cc.Value(3))
+			if err != nil {
+				cc.MessageError = err.Error()
+				return cc, nil
+			}
+
+			options := docker.CommitOptions{
+				Reference: reference,
+				Author:    cc.Value(1),
+				Message:   cc.Value(2),
+				Changes:   changes,
+			}
+			imageID := new(string)
+			updates := commitContainer(m.dockerClient, cc.containerID, cc.container, options, imageID)
+			return cc, m.startProgress("Commit "+cc.container+" to "+reference, MImageList, updates, func(m *model) tea.Cmd {
+				images, err := m.dockerClient.ImageList()
+				if err != nil {
+					fmt.Println(err)
+				}
+
+				m.imageList = NewImageList(images, "")
+				m.imageList.selectImage(*imageID)
+				return nil
+			})
+		}
+	}
+
+	var cmd tea.Cmd
+	cc.Form, cmd = cc.Form.Update(msg)
+	return cc, cmd
+}
+
+// commitContainer creates the image in the background, imageID is set before updates is closed.
+func commitContainer(dockerClient *docker.Docker, containerID string, container string, options docker.CommitOptions, imageID *string) <-chan progressUpdate {
+	updates := make(chan progressUpdate)
+
+	go func() {
+		defer close(updates)
+
+		updates <- progressUpdate{text: fmt.Sprintf("Committing %s, the container is paused meanwhile", container)}
+		id, err := dockerClient.ContainerCommit(containerID, options)
+		if err != nil {
+			updates <- progressUpdate{err: err}
+			return
+		}
+
+		*imageID = id
+		updates <- progressUpdate{text: "Created image " + options.Reference + " " + id}
+	}()
+
+	return updates
+}
+
+// parseCommitChanges splits the changes by ; keeping the ones inside quotes or brackets, like in CMD ["sh", "-c", "a; b"].
+func parseCommitChanges(value string) ([]string, error) {
+	parts := []string{}
+	current := strings.Builder{}
+	var quote rune
+	depth := 0
+
+	for _, r := range value {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '"' || r == '\'':
+			quote = r
+		case r == '[':
+			depth++
+		case r == ']' && depth > 0:
+			depth--
+		case r == ';' && depth == 0:
+			parts = append(parts, current.String())
+			current.Reset()
+			continue
+		}
+		current.WriteRune(r)
+	}
+	parts = append(parts, current.String())
+
+	changes := []string{}
+	for _, part := range parts {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		instruction := strings.ToUpper(strings.Fields(part)[0])
+		valid := false
+		for _, i := range commitInstructions {
+			if instruction == i {
+				valid = true
+			}
+		}
+		if !valid {
+			return nil, fmt.Errorf("%s is not supported in changes, use one of %s", instruction, strings.Join(commitInstructions, ", "))
+		}
+
+		changes = append(changes, part)
+	}
+
+	return changes, nil
+}
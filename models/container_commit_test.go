@@ -0,0 +1,48 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseCommitChanges(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name:  "should return no changes for an empty value",
+			value: "",
+			want:  []string{},
+		},
+		{
+			name:  "should split instructions by semicolon",
+			value: "ENV DEBUG=1; EXPOSE 8080 ;",
+			want:  []string{"ENV DEBUG=1", "EXPOSE 8080"},
+		},
+		{
+			name:  "should keep semicolons inside brackets and quotes",
+			value: `CMD ["nginx", "-g", "daemon off;"]; LABEL note='a;b'`,
+			want:  []string{`CMD ["nginx", "-g", "daemon off;"]`, "LABEL note='a;b'"},
+		},
+		{
+			name:    "should reject instructions not supported by commit",
+			value:   "RUN apt-get update",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseCommitChanges(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseCommitChanges() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseCommitChanges() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
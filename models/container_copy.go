@@ -0,0 +1,185 @@
+package models
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/ernesto27/dcli/docker"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type ContainerCopy struct {
+	Form
+	containerID string
+	upload      bool
+	source      string
+	confirm     bool
+}
+
+func NewContainerDownload(containerID string, source string) ContainerCopy {
+	return ContainerCopy{
+		Form: NewForm([]FormField{
+			{Label: "Host destination directory", Placeholder: ".", Value: "."},
+		}),
+		containerID: containerID,
+		source:      source,
+	}
+}
+
+func NewContainerUpload(containerID string, dir string) ContainerCopy {
+	return ContainerCopy{
+		Form: NewForm([]FormField{
+			{Label: "Host file or directory", Placeholder: "./config"},
+			{Label: "Container destination directory", Placeholder: "/", Value: dir},
+		}),
+		containerID: containerID,
+		upload:      true,
+	}
+}
+
+func (cc ContainerCopy) View() string {
+	if cc.upload {
+		return cc.Form.View("Upload to container")
+	}
+	return cc.Form.View("Download " + cc.source)
+}
+
+func (cc ContainerCopy) Update(msg tea.Msg, m *model) (ContainerCopy, tea.Cmd) {
+	if m.currentModel != MContainerCopy {
+		return cc, nil
+	}
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "enter":
+			if cc.upload {
+				return cc.startUpload(m)
+			}
+			return cc.startDownload(m)
+		default:
+			cc.confirm = false
+			cc.MessageError = ""
+		}
+	}
+
+	var cmd tea.Cmd
+	cc.Form, cmd = cc.Form.Update(msg)
+	return cc, cmd
+}
+
+func (cc ContainerCopy) startDownload(m *model) (ContainerCopy, tea.Cmd) {
+	dest := cc.Value(0)
+	info, err := os.Stat(dest)
+	if err != nil {
+		cc.MessageError = err.Error()
+		return cc, nil
+	}
+	if !info.IsDir() {
+		cc.MessageError = dest + " is not a directory"
+		return cc, nil
+	}
+
+	target := filepath.Join(dest, path.Base(cc.source))
+	if _, err := os.Stat(target); err == nil && !cc.confirm {
+		cc.confirm = true
+		cc.MessageError = target + " already exists, press enter again to overwrite"
+		return cc, nil
+	}
+
+	title := fmt.Sprintf("Download %s to %s", cc.source, target)
+	return cc, m.startProgress(title, MContainerFiles, downloadFiles(m.dockerClient, cc.containerID, cc.source, dest), nil)
+}
+
+func (cc ContainerCopy) startUpload(m *model) (ContainerCopy, tea.Cmd) {
+	source := cc.Value(0)
+	if _, err := os.Stat(source); err != nil {
+		cc.MessageError = err.Error()
+		return cc, nil
+	}
+
+	dir := cc.Value(1)
+	stat, err := m.dockerClient.ContainerStatPath(cc.containerID, dir)
+	if err != nil {
+		cc.MessageError = err.Error()
+		return cc, nil
+	}
+	if !stat.IsDir() {
+		cc.MessageError = dir + " is not a directory in the container"
+		return cc, nil
+	}
+
+	target := path.Join(dir, filepath.Base(source))
+	if _, err := m.dockerClient.ContainerStatPath(cc.containerID, target); err == nil && !cc.confirm {
+		cc.confirm = true
+		cc.MessageError = target + " already exists in the container, press enter again to overwrite"
+		return cc, nil
+	}
+
+	title := fmt.Sprintf("Upload %s to %s", source, target)
+	updates := uploadFiles(m.dockerClient, cc.containerID, source, dir)
+	return cc, m.startProgress(title, MContainerFiles, updates, func(m *model) tea.Cmd {
+		return m.containerFiles.reload(dir, m.dockerClient)
+	})
+}
+
+func downloadFiles(dockerClient *docker.Docker, containerID string, source string, dest string) <-chan progressUpdate {
+	updates := make(chan progressUpdate)
+
+	go func() {
+		defer close(updates)
+
+		var total int64
+		if stat, err := dockerClient.ContainerStatPath(containerID, source); err == nil && !stat.IsDir() {
+			total = stat.Size
+		}
+
+		reader, err := dockerClient.CopyFromContainer(containerID, source)
+		if err != nil {
+			updates <- progressUpdate{err: err}
+			return
+		}
+		defer reader.Close()
+
+		pw := &progressWriter{updates: updates, label: "Downloaded", total: total}
+		if err := docker.ExtractTar(io.TeeReader(reader, pw), dest); err != nil {
+			updates <- progressUpdate{err: err}
+			return
+		}
+
+		pw.report()
+		updates <- progressUpdate{text: fmt.Sprintf("Copied %s to %s", source, dest)}
+	}()
+
+	return updates
+}
+
+func uploadFiles(dockerClient *docker.Docker, containerID string, source string, dir string) <-chan progressUpdate {
+	updates := make(chan progressUpdate)
+
+	go func() {
+		defer close(updates)
+
+		reader, writer := io.Pipe()
+		go func() {
+			writer.CloseWithError(docker.WriteTar(writer, source))
+		}()
+
+		pw := &progressWriter{updates: updates, label: "Uploaded", total: docker.HostPathSize(source)}
+		err := dockerClient.CopyToContainer(containerID, dir, io.TeeReader(reader, pw))
+		reader.Close()
+		if err != nil {
+			updates <- progressUpdate{err: err}
+			return
+		}
+
+		pw.report()
+		updates <- progressUpdate{text: fmt.Sprintf("Copied %s to %s", source, dir)}
+	}()
+
+	return updates
+}
@@ -2,87 +2,389 @@ package models
 
 import (
 	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/ernesto27/dcli/utils"
 
 	"github.com/ernesto27/dcli/docker"
 
+	"github.com/charmbracelet/bubbles/table"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/glamour"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
 )
 
-type ContainerDetail struct {
-	viewport viewport.Model
+const containerDetailWidth = 120
+
+// maxChangesRows limits the paths listed in the changes tab, the directory summary counts all of them.
+const maxChangesRows = 500
+
+type containerChangesMsg struct {
+	containerID string
+	changes     []container.FilesystemChange
+	err         error
 }
 
-func NewContainerDetail(container docker.MyContainer, createTable utils.CreateTableFunc) (ContainerDetail, error) {
-	content := getContent(container)
+const (
+	detailTab = iota
+	changesTab
+	healthTab
+	mountsTab
+)
 
-	const width = 120
+var containerDetailTabs = []string{"Detail", "Changes", "Health", "Mounts"}
 
-	vp := viewport.New(width, 30)
+type ContainerDetail struct {
+	viewport  viewport.Model
+	container docker.MyContainer
+	detail    string
+	changes   string
+	mounts    table.Model
+	tab       int
+	loading   bool
+}
+
+func NewContainerDetail(container docker.MyContainer, createTable utils.CreateTableFunc) (ContainerDetail, error) {
+	vp := viewport.New(containerDetailWidth, 30)
 	vp.Style = lipgloss.NewStyle().
 		BorderStyle(lipgloss.RoundedBorder()).
 		BorderForeground(lipgloss.Color("62")).
 		PaddingRight(2)
 
-	renderer, err := glamour.NewTermRenderer(
-		glamour.WithAutoStyle(),
-		glamour.WithWordWrap(width),
-	)
+	str, err := renderContainerDetail(getContent(container))
 	if err != nil {
 		return ContainerDetail{}, err
 	}
 
-	str, err := renderer.Render(content)
-	if err != nil {
-		return ContainerDetail{}, err
+	vp.SetContent(str)
+
+	return ContainerDetail{viewport: vp, container: container, detail: str, mounts: newMountsTable(container.Mounts)}, nil
+}
+
+func newMountsTable(mounts []types.MountPoint) table.Model {
+	columns := []table.Column{
+		{Title: "Type", Width: 8},
+		{Title: "Source", Width: 40},
+		{Title: "Destination", Width: 30},
+		{Title: "Mode", Width: 8},
+		{Title: "RW", Width: 4},
+		{Title: "Propagation", Width: 12},
 	}
 
-	vp.SetContent(str)
+	t := table.New(
+		table.WithColumns(columns),
+		table.WithRows(mountRows(mounts)),
+		table.WithFocused(true),
+		table.WithHeight(15),
+	)
+
+	s := table.DefaultStyles()
+	s.Header = s.Header.
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderForeground(lipgloss.Color("240")).
+		BorderBottom(true).
+		Bold(false)
+	s.Selected = s.Selected.
+		Foreground(lipgloss.Color("229")).
+		Background(lipgloss.Color("57")).
+		Bold(false)
+	t.SetStyles(s)
+
+	return t
+}
+
+// mountRows lists the mounts sorted by destination, volumes show the volume name as source.
+func mountRows(mounts []types.MountPoint) []table.Row {
+	sorted := append([]types.MountPoint{}, mounts...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Destination < sorted[j].Destination
+	})
+
+	rows := []table.Row{}
+	for _, mount := range sorted {
+		source := mount.Source
+		if mount.Type == "volume" {
+			source = mount.Name
+		}
+
+		rw := "ro"
+		if mount.RW {
+			rw = "rw"
+		}
+
+		rows = append(rows, table.Row{string(mount.Type), source, mount.Destination, mount.Mode, rw, string(mount.Propagation)})
+	}
+	return rows
+}
+
+func renderContainerDetail(content string) (string, error) {
+	renderer, err := glamour.NewTermRenderer(
+		glamour.WithAutoStyle(),
+		glamour.WithWordWrap(containerDetailWidth),
+	)
+	if err != nil {
+		return "", err
+	}
 
-	return ContainerDetail{viewport: vp}, nil
+	return renderer.Render(content)
 }
 
 func (cd ContainerDetail) View() string {
-	return cd.viewport.View() + helpStyle("\n  ↑/↓: Navigate • Esc: back to list\n")
+	active := lipgloss.NewStyle().Foreground(lipgloss.Color("229")).Background(lipgloss.Color("57")).Padding(0, 1)
+	inactive := lipgloss.NewStyle().Padding(0, 1)
+
+	tabs := ""
+	for i, tab := range containerDetailTabs {
+		if i == cd.tab {
+			tabs += active.Render(tab)
+		} else {
+			tabs += inactive.Render(tab)
+		}
+	}
+
+	if cd.tab == mountsTab {
+		if len(cd.mounts.Rows()) == 0 {
+			return tabs + "\n\nThe container has no mounts\n" + helpStyle("\n  tab/shift+tab: Detail/Changes/Health/Mounts • Esc: back to list\n")
+		}
+		return tabs + "\n\n" + cd.mounts.View() + helpStyle("\n  ↑/↓: Navigate • enter: Volume detail • tab/shift+tab: Detail/Changes/Health/Mounts • Esc: back to list\n")
+	}
+
+	return tabs + "\n" + cd.viewport.View() + helpStyle("\n  ↑/↓: Navigate • tab/shift+tab: Detail/Changes/Health/Mounts • Esc: back to list\n")
 }
 
-func (cd ContainerDetail) Update(msg tea.Msg, m *model) (viewport.Model, tea.Cmd) {
+func (cd ContainerDetail) Update(msg tea.Msg, m *model) (ContainerDetail, tea.Cmd) {
 	switch msg := msg.(type) {
+	case containerChangesMsg:
+		if msg.containerID != cd.container.ID {
+			return cd, nil
+		}
+
+		cd.loading = false
+		if msg.err != nil {
+			cd.changes = "Error: " + msg.err.Error()
+		} else {
+			str, err := renderContainerDetail(getContentChanges(cd.container, msg.changes))
+			if err != nil {
+				str = "Error: " + err.Error()
+			}
+			cd.changes = str
+		}
+
+		if cd.tab == changesTab {
+			cd.viewport.SetContent(cd.changes)
+			cd.viewport.GotoTop()
+		}
+		return cd, nil
+
 	case tea.KeyMsg:
 		switch msg.String() {
 		case "esc":
-			m.setContainerList()
+			if m.currentModel == MContainerDetail {
+				m.setContainerList()
+			}
+		case "tab", "shift+tab":
+			if m.currentModel != MContainerDetail {
+				break
+			}
+
+			if msg.String() == "tab" {
+				cd.tab = (cd.tab + 1) % len(containerDetailTabs)
+			} else {
+				cd.tab = (cd.tab + len(containerDetailTabs) - 1) % len(containerDetailTabs)
+			}
+			cd.viewport.GotoTop()
+
+			switch cd.tab {
+			case detailTab:
+				cd.viewport.SetContent(cd.detail)
+			case changesTab:
+				if cd.changes != "" {
+					cd.viewport.SetContent(cd.changes)
+					return cd, nil
+				}
+
+				cd.viewport.SetContent("Loading changes...")
+				if !cd.loading {
+					cd.loading = true
+					return cd, cd.loadChanges(m.dockerClient)
+				}
+			case healthTab:
+				// the health log changes with every probe, it is loaded each time the tab is shown
+				health, healthcheck, err := m.dockerClient.ContainerHealth(cd.container.ID)
+				str := ""
+				if err == nil {
+					str, err = renderContainerDetail(getContentHealth(health, healthcheck))
+				}
+				if err != nil {
+					str = "Error: " + err.Error()
+				}
+				cd.viewport.SetContent(str)
+			}
+			return cd, nil
 		}
 	}
 
+	if cd.tab == mountsTab {
+		if m.currentModel != MContainerDetail {
+			return cd, nil
+		}
+
+		if msg, ok := msg.(tea.KeyMsg); ok && msg.String() == "enter" {
+			return cd, cd.openVolume(m)
+		}
+
+		cd.mounts, _ = cd.mounts.Update(msg)
+		return cd, nil
+	}
+
 	cd.viewport, _ = cd.viewport.Update(msg)
-	return cd.viewport, nil
+	return cd, nil
 }
 
-func getContent(container docker.MyContainer) string {
-	response := ""
+func (cd ContainerDetail) openVolume(m *model) tea.Cmd {
+	row := cd.mounts.SelectedRow()
+	if len(row) == 0 || row[0] != "volume" {
+		return nil
+	}
 
-	response += utils.CreateTable("# Container status", []string{"Type", "Value"},
-		[][]string{
-			{"ID", container.ID},
-			{"Name", container.Name},
-			{"Image", container.Image},
-			{"Status", container.State},
-			{"Created", container.Status},
-		})
+	if _, err := m.dockerClient.VolumeList(); err != nil {
+		fmt.Println(err)
+	}
+
+	v, err := m.dockerClient.GetVolumeByName(row[1])
+	if err != nil {
+		fmt.Println(err)
+		return nil
+	}
+
+	vd, err := NewVolumeDetail(v, utils.CreateTable)
+	if err != nil {
+		fmt.Println(err)
+	}
+	m.volumeDetail = vd
+	m.currentModel = MVolumeDetail
+	return tea.ClearScreen
+}
+
+func (cd ContainerDetail) loadChanges(dockerClient *docker.Docker) tea.Cmd {
+	containerID := cd.container.ID
+	return func() tea.Msg {
+		changes, err := dockerClient.ContainerChanges(containerID)
+		return containerChangesMsg{containerID: containerID, changes: changes, err: err}
+	}
+}
+
+func getContentNetworking(container docker.MyContainer) string {
+	rows := [][]string{}
+	for _, p := range docker.FormatPorts(container.Ports) {
+		rows = append(rows, []string{p})
+	}
+	if len(rows) == 0 {
+		rows = append(rows, []string{"No ports exposed"})
+	}
+	response := utils.CreateTable("# Ports", []string{"Host -> Container"}, rows)
+
+	rows = [][]string{}
+	for _, e := range container.Networks {
+		rows = append(rows, []string{e.Name, e.IPAddress, e.IPv6Address, e.Gateway, e.MacAddress, strings.Join(e.Aliases, ", ")})
+	}
+	if len(rows) == 0 {
+		rows = append(rows, []string{container.Network.Name, container.Network.IPAddress, "", container.Network.Gateway, "", ""})
+	}
 
 	response += "\n\n---\n\n"
+	response += utils.CreateTable("# Networking", []string{"Network", "IP Address", "IPv6 Address", "Gateway", "MAC Address", "Aliases"}, rows)
+	return response
+}
+
+func exitCodeDescription(exitCode int, oomKilled bool) string {
+	description := strconv.Itoa(exitCode)
+	switch {
+	case oomKilled:
+		description += " (killed, out of memory)"
+	case exitCode == 137:
+		description += " (killed with SIGKILL)"
+	case exitCode == 143:
+		description += " (stopped with SIGTERM)"
+	}
+	return description
+}
+
+func getContentChanges(c docker.MyContainer, changes []container.FilesystemChange) string {
+	response := utils.CreateTable("# Writable layer", []string{"Type", "Value"}, [][]string{
+		{"Size", utils.FormatSize(c.SizeRw)},
+		{"Size with image", c.Size},
+		{"Changed paths", strconv.Itoa(len(changes))},
+	})
+
+	if len(changes) == 0 {
+		return response + "\n\nNo changes since the container was created from the image"
+	}
+
 	rows := [][]string{}
+	for _, g := range docker.GroupChanges(changes) {
+		rows = append(rows, []string{escapeTableCell(g.Dir), strconv.Itoa(g.Added), strconv.Itoa(g.Changed), strconv.Itoa(g.Deleted)})
+	}
+	response += "\n\n---\n\n"
+	response += utils.CreateTable("# Changes by directory", []string{"Directory", "Added", "Changed", "Deleted"}, rows)
+
+	rows = [][]string{}
+	for i, change := range changes {
+		if i == maxChangesRows {
+			rows = append(rows, []string{"", fmt.Sprintf("... %d more", len(changes)-maxChangesRows)})
+			break
+		}
+		rows = append(rows, []string{changeKind(change.Kind), escapeTableCell(change.Path)})
+	}
+	response += "\n\n---\n\n"
+	response += utils.CreateTable("# Paths", []string{"Kind", "Path"}, rows)
+
+	return response
+}
+
+func changeKind(kind container.ChangeType) string {
+	switch kind {
+	case container.ChangeAdd:
+		return "Added"
+	case container.ChangeDelete:
+		return "Deleted"
+	default:
+		return "Changed"
+	}
+}
+
+func getContent(container docker.MyContainer) string {
+	response := ""
 
-	ports := ""
-	for _, c := range container.Ports {
-		ports += fmt.Sprintf("%s:%d->%d/%s ", c.IP, c.PublicPort, c.PrivatePort, c.Type)
+	rows := [][]string{
+		{"ID", container.ID},
+		{"Name", container.Name},
+		{"Image", container.Image},
+		{"Status", container.State},
+		{"Created", container.Status},
 	}
-	rows = append(rows, []string{"Ports", ports})
+	if health := container.HealthStatus(); health != "" {
+		rows = append(rows, []string{"Health", health})
+	}
+	if !container.FinishedAt.IsZero() {
+		rows = append(rows, []string{"Last exit code", exitCodeDescription(container.ExitCode, container.OOMKilled)})
+		rows = append(rows, []string{"Finished", container.FinishedAt.Local().Format("2006-01-02 15:04:05")})
+	}
+	rows = append(rows, []string{"Restart count", strconv.Itoa(container.RestartCount)})
+	if container.CrashLoop {
+		rows = append(rows, []string{"Warning", "the container is crash looping, check the logs"})
+	}
+	response += utils.CreateTable("# Container status", []string{"Type", "Value"}, rows)
+
+	response += "\n\n---\n\n"
+	rows = [][]string{}
+
 	rows = append(rows, []string{"Command", container.Command})
 
 	for _, env := range container.Env {
@@ -92,9 +394,7 @@ func getContent(container docker.MyContainer) string {
 	response += utils.CreateTable("# Container detail", []string{"Type", "Value"}, rows)
 
 	response += "\n\n---\n\n"
-	response += utils.CreateTable("# Networking", []string{"Network", "IP Address", "Gateway"}, [][]string{
-		{container.Network.Name, container.Network.IPAddress, container.Network.Gateway},
-	})
+	response += getContentNetworking(container)
 
 	response += "\n\n---\n\n"
 
@@ -102,3 +402,65 @@ func getContent(container docker.MyContainer) string {
 
 	return response
 }
+
+func getContentHealth(health *types.Health, healthcheck *container.HealthConfig) string {
+	if healthcheck == nil || len(healthcheck.Test) == 0 || healthcheck.Test[0] == "NONE" {
+		return "# Health\n\nThe container has no healthcheck"
+	}
+
+	status := types.NoHealthcheck
+	failingStreak := 0
+	if health != nil {
+		status = health.Status
+		failingStreak = health.FailingStreak
+	}
+
+	test := healthcheck.Test
+	if test[0] == "CMD" || test[0] == "CMD-SHELL" {
+		test = test[1:]
+	}
+
+	rows := [][]string{
+		{"Status", status},
+		{"Failing streak", strconv.Itoa(failingStreak)},
+		{"Test", escapeTableCell(strings.Join(test, " "))},
+	}
+	for _, r := range [][]string{
+		{"Interval", formatDuration(healthcheck.Interval)},
+		{"Timeout", formatDuration(healthcheck.Timeout)},
+		{"Start period", formatDuration(healthcheck.StartPeriod)},
+		{"Retries", formatInt(int64(healthcheck.Retries))},
+	} {
+		if r[1] != "" {
+			rows = append(rows, r)
+		}
+	}
+	response := utils.CreateTable("# Health", []string{"Type", "Value"}, rows)
+
+	if health == nil || len(health.Log) == 0 {
+		return response + "\n\nNo probes yet"
+	}
+
+	rows = [][]string{}
+	for i := len(health.Log) - 1; i >= 0; i-- {
+		probe := health.Log[i]
+		output := strings.Join(strings.Fields(probe.Output), " ")
+		rows = append(rows, []string{
+			probe.Start.Local().Format("2006-01-02 15:04:05"),
+			probe.End.Sub(probe.Start).Round(time.Millisecond).String(),
+			strconv.Itoa(probe.ExitCode),
+			escapeTableCell(utils.TrimValue(output, 80)),
+		})
+	}
+	response += "\n\n---\n\n"
+	response += utils.CreateTable("# Last probes", []string{"Start", "Duration", "Exit code", "Output"}, rows)
+
+	return response
+}
+
+func formatDuration(d time.Duration) string {
+	if d == 0 {
+		return ""
+	}
+	return d.String()
+}
@@ -0,0 +1,27 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/docker/docker/api/types"
+)
+
+func TestMountRows(t *testing.T) {
+	mounts := []types.MountPoint{
+		{Type: "volume", Name: "pgdata", Source: "/var/lib/docker/volumes/pgdata/_data", Destination: "/var/lib/postgresql/data", Driver: "local", RW: true},
+		{Type: "bind", Source: "/home/user/conf", Destination: "/etc/nginx", Mode: "ro", RW: false, Propagation: "rprivate"},
+		{Type: "tmpfs", Destination: "/run", RW: true},
+	}
+
+	want := []table.Row{
+		{"bind", "/home/user/conf", "/etc/nginx", "ro", "ro", "rprivate"},
+		{"tmpfs", "", "/run", "", "rw", ""},
+		{"volume", "pgdata", "/var/lib/postgresql/data", "", "rw", ""},
+	}
+
+	if got := mountRows(mounts); !reflect.DeepEqual(got, want) {
+		t.Errorf("mountRows() = %v, want %v", got, want)
+	}
+}
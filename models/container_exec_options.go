@@ -2,39 +2,77 @@ package models
 
 import (
 	"fmt"
+	"strings"
+
+	"github.com/ernesto27/dcli/docker"
+	"github.com/ernesto27/dcli/utils"
 
 	tea "github.com/charmbracelet/bubbletea"
 )
 
-type ContainerExecOptions struct {
-	Options
+type containerShellsMsg struct {
+	containerID string
+	shells      []string
 }
 
-const (
-	Bash = "bin/bash"
-	Sh   = "bin/sh"
-	Ash  = "bin/ash"
-)
-
-func NewContainerExecOptions(container string) ContainerExecOptions {
-	choices := []string{Bash, Sh, Ash}
+type ContainerExecOptions struct {
+	Form
+	containerID string
+	container   string
+	shells      []string
+	detecting   bool
+}
 
+func NewContainerExecOptions(containerID string, container string) ContainerExecOptions {
 	return ContainerExecOptions{
-		Options{
-			Cursor:  0,
-			Choice:  "",
-			Choices: choices,
-			Text1:   container,
-		},
+		Form: NewForm([]FormField{
+			{Label: "Command", Placeholder: "/bin/sh"},
+			{Label: "User (optional)", Placeholder: "root"},
+			{Label: "Working directory (optional)", Placeholder: "/app"},
+			{Label: "Env vars (KEY=VALUE separated by comma, optional)", Placeholder: "DEBUG=1, TERM=xterm"},
+		}),
+		containerID: containerID,
+		container:   container,
+		detecting:   true,
+	}
+}
+
+func (o ContainerExecOptions) detectShells(dockerClient *docker.Docker) tea.Cmd {
+	containerID := o.containerID
+	return func() tea.Msg {
+		return containerShellsMsg{containerID: containerID, shells: dockerClient.ContainerShells(containerID)}
 	}
 }
 
 func (o ContainerExecOptions) View() string {
-	title := fmt.Sprintf("Exec command container: %s", o.Text1)
-	return o.Options.View(title)
+	title := fmt.Sprintf("Exec command container: %s", o.container)
+
+	shells := "Detecting shells..."
+	if !o.detecting {
+		shells = "Shells found: " + strings.Join(o.shells, ", ")
+		if len(o.shells) == 0 {
+			shells = "No shell found in the container, enter a command"
+		}
+	}
+
+	return o.Form.View(title) + "\n" + helpStyle(shells) + "\n"
 }
 
 func (o ContainerExecOptions) Update(msg tea.Msg, m *model) (ContainerExecOptions, tea.Cmd) {
+	switch msg := msg.(type) {
+	case containerShellsMsg:
+		if msg.containerID != o.containerID {
+			return o, nil
+		}
+
+		o.detecting = false
+		o.shells = msg.shells
+		if len(o.shells) > 0 && o.Value(0) == "" {
+			o.SetValue(0, o.shells[0])
+		}
+		return o, nil
+	}
+
 	if m.currentModel != MContainerExecOptions {
 		return o, nil
 	}
@@ -42,18 +80,45 @@ func (o ContainerExecOptions) Update(msg tea.Msg, m *model) (ContainerExecOption
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		switch msg.String() {
-		case "down":
-			o.Cursor++
-			if o.Cursor >= len(o.Choices) {
-				o.Cursor = 0
-			}
-		case "up":
-			o.Cursor--
-			if o.Cursor < 0 {
-				o.Cursor = len(o.Choices) - 1
+		case "enter":
+			options, err := o.options()
+			if err != nil {
+				o.MessageError = err.Error()
+				return o, nil
 			}
+
+			m.currentModel = MContainerList
+			return o, tea.Exec(m.dockerClient.ContainerExec(o.containerID, options), func(err error) tea.Msg {
+				return attachExited{err}
+			})
+		}
+	}
+
+	var cmd tea.Cmd
+	o.Form, cmd = o.Form.Update(msg)
+	return o, cmd
+}
+
+func (o ContainerExecOptions) options() (docker.ExecOptions, error) {
+	command, err := utils.SplitCommand(o.Value(0))
+	if err != nil {
+		return docker.ExecOptions{}, err
+	}
+	if len(command) == 0 {
+		return docker.ExecOptions{}, fmt.Errorf("command is required")
+	}
+
+	env := []string{}
+	for _, e := range strings.Split(o.Value(3), ",") {
+		if strings.TrimSpace(e) != "" {
+			env = append(env, strings.TrimSpace(e))
 		}
 	}
 
-	return o, nil
+	return docker.ExecOptions{
+		Cmd:        command,
+		User:       o.Value(1),
+		WorkingDir: o.Value(2),
+		Env:        env,
+	}, nil
 }
@@ -0,0 +1,105 @@
+package models
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/ernesto27/dcli/docker"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type ContainerExport struct {
+	Form
+	containerID string
+	container   string
+	size        int64
+}
+
+func NewContainerExport(containerID string, container string, size int64) ContainerExport {
+	return ContainerExport{
+		Form: NewForm([]FormField{
+			{Label: "Output file (.tar, .tar.gz to compress)", Placeholder: "container.tar", Value: container + ".tar"},
+		}),
+		containerID: containerID,
+		container:   container,
+		size:        size,
+	}
+}
+
+func (ce ContainerExport) View() string {
+	return ce.Form.View(fmt.Sprintf("Export filesystem of container: %s", ce.container))
+}
+
+func (ce ContainerExport) Update(msg tea.Msg, m *model) (ContainerExport, tea.Cmd) {
+	if m.currentModel != MContainerExport {
+		return ce, nil
+	}
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "enter":
+			path := ce.Value(0)
+			if path == "" {
+				ce.MessageError = "output file is required"
+				return ce, nil
+			}
+
+			title := fmt.Sprintf("Export %s to %s", ce.container, path)
+			updates := exportContainer(m.dockerClient, ce.containerID, ce.size, path)
+			return ce, m.startProgress(title, MContainerList, updates, nil)
+		}
+	}
+
+	var cmd tea.Cmd
+	ce.Form, cmd = ce.Form.Update(msg)
+	return ce, cmd
+}
+
+func exportContainer(dockerClient *docker.Docker, containerID string, size int64, path string) <-chan progressUpdate {
+	updates := make(chan progressUpdate)
+
+	go func() {
+		defer close(updates)
+
+		body, err := dockerClient.ContainerExport(containerID)
+		if err != nil {
+			updates <- progressUpdate{err: err}
+			return
+		}
+		defer body.Close()
+
+		f, err := os.Create(path)
+		if err != nil {
+			updates <- progressUpdate{err: err}
+			return
+		}
+		defer f.Close()
+
+		var out io.Writer = f
+		var gz *gzip.Writer
+		if strings.HasSuffix(path, ".gz") || strings.HasSuffix(path, ".tgz") {
+			gz = gzip.NewWriter(f)
+			out = gz
+		}
+
+		pw := &progressWriter{updates: updates, label: "Exported", total: size}
+		_, err = io.Copy(io.MultiWriter(out, pw), body)
+		if err == nil && gz != nil {
+			err = gz.Close()
+		}
+		if err != nil {
+			updates <- progressUpdate{err: err}
+			return
+		}
+
+		pw.report()
+		updates <- progressUpdate{text: "Container filesystem exported to " + path}
+	}()
+
+	return updates
+}
@@ -0,0 +1,137 @@
+package models
+
+import (
+	"bytes"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/ernesto27/dcli/docker"
+	"github.com/ernesto27/dcli/utils"
+
+	"github.com/alecthomas/chroma"
+	"github.com/alecthomas/chroma/formatters"
+	"github.com/alecthomas/chroma/lexers"
+	"github.com/alecthomas/chroma/styles"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const maxFileViewSize = 1024 * 1024
+
+type containerFileContentMsg struct {
+	containerID string
+	path        string
+	content     string
+	err         error
+}
+
+type ContainerFileView struct {
+	viewport    viewport.Model
+	containerID string
+	file        docker.ContainerFile
+	loading     bool
+	err         error
+}
+
+func NewContainerFileView(containerID string, file docker.ContainerFile) ContainerFileView {
+	vp := viewport.New(140, 25)
+	vp.Style = lipgloss.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("62")).
+		PaddingRight(2)
+
+	return ContainerFileView{
+		viewport:    vp,
+		containerID: containerID,
+		file:        file,
+		loading:     true,
+	}
+}
+
+func (fv ContainerFileView) load(dockerClient *docker.Docker) tea.Cmd {
+	containerID := fv.containerID
+	p := fv.file.Path
+	return func() tea.Msg {
+		data, truncated, err := dockerClient.ReadContainerFile(containerID, p, maxFileViewSize)
+		if err != nil {
+			return containerFileContentMsg{containerID: containerID, path: p, err: err}
+		}
+		if isBinary(data) {
+			return containerFileContentMsg{containerID: containerID, path: p, content: "Binary file, use ctrl+d on the file list to download it"}
+		}
+
+		content := highlightCode(path.Base(p), strings.ReplaceAll(string(data), "\t", "    "))
+		if truncated {
+			content += fmt.Sprintf("\n\n... only the first %s are shown", utils.FormatSize(maxFileViewSize))
+		}
+		return containerFileContentMsg{containerID: containerID, path: p, content: content}
+	}
+}
+
+func (fv ContainerFileView) View() string {
+	title := titleTableStyle("FILE " + fv.file.Path)
+	info := fmt.Sprintf("  %s • %s • %s", fv.file.Mode.String(), utils.FormatSize(fv.file.Size), fv.file.ModTime.Local().Format("2006-01-02 15:04:05"))
+	help := helpStyle("\n  ↑/↓: Scroll • Esc: back\n")
+
+	if fv.loading {
+		return title + "\n" + info + "\n\nLoading..." + help
+	}
+	if fv.err != nil {
+		return title + "\n" + info + "\n\nError: " + fv.err.Error() + help
+	}
+
+	return title + "\n" + info + "\n" + fv.viewport.View() + help
+}
+
+func (fv ContainerFileView) Update(msg tea.Msg, m *model) (ContainerFileView, tea.Cmd) {
+	switch msg := msg.(type) {
+	case containerFileContentMsg:
+		if msg.containerID != fv.containerID || msg.path != fv.file.Path {
+			return fv, nil
+		}
+
+		fv.loading = false
+		fv.err = msg.err
+		fv.viewport.SetContent(msg.content)
+		return fv, nil
+	}
+
+	if m.currentModel != MContainerFileView {
+		return fv, nil
+	}
+
+	var cmd tea.Cmd
+	fv.viewport, cmd = fv.viewport.Update(msg)
+	return fv, cmd
+}
+
+// isBinary reports whether data looks like a binary file, checking for NUL bytes like git does.
+func isBinary(data []byte) bool {
+	if len(data) > 8000 {
+		data = data[:8000]
+	}
+	return bytes.IndexByte(data, 0) >= 0
+}
+
+func highlightCode(name string, content string) string {
+	lexer := lexers.Match(name)
+	if lexer == nil {
+		lexer = lexers.Analyse(content)
+	}
+	if lexer == nil {
+		lexer = lexers.Fallback
+	}
+
+	iterator, err := chroma.Coalesce(lexer).Tokenise(nil, content)
+	if err != nil {
+		return content
+	}
+
+	var b strings.Builder
+	if err := formatters.TTY256.Format(&b, styles.Monokai, iterator); err != nil {
+		return content
+	}
+	return b.String()
+}
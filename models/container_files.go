@@ -0,0 +1,316 @@
+package models
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/ernesto27/dcli/docker"
+	"github.com/ernesto27/dcli/utils"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const filesHeight = 25
+
+type containerFilesMsg struct {
+	containerID string
+	dir         string
+	files       []docker.ContainerFile
+	err         error
+}
+
+type containerLinkMsg struct {
+	containerID string
+	target      docker.ContainerFile
+	err         error
+}
+
+type fileRow struct {
+	file  docker.ContainerFile
+	depth int
+}
+
+type ContainerFiles struct {
+	containerID string
+	container   string
+	root        string
+	children    map[string][]docker.ContainerFile
+	expanded    map[string]bool
+	loading     map[string]bool
+	rows        []fileRow
+	cursor      int
+	offset      int
+	err         error
+}
+
+func NewContainerFiles(containerID string, container string) ContainerFiles {
+	return ContainerFiles{
+		containerID: containerID,
+		container:   container,
+		root:        "/",
+		children:    map[string][]docker.ContainerFile{},
+		expanded:    map[string]bool{},
+		loading:     map[string]bool{"/": true},
+	}
+}
+
+func (cf ContainerFiles) load(dockerClient *docker.Docker) tea.Cmd {
+	return cf.listDir(cf.root, dockerClient)
+}
+
+func (cf ContainerFiles) listDir(dir string, dockerClient *docker.Docker) tea.Cmd {
+	containerID := cf.containerID
+	return func() tea.Msg {
+		files, err := dockerClient.ContainerListDir(containerID, dir)
+		return containerFilesMsg{containerID: containerID, dir: dir, files: files, err: err}
+	}
+}
+
+// open sets dir as the root of the tree.
+func (cf *ContainerFiles) open(dir string, dockerClient *docker.Docker) tea.Cmd {
+	cf.root = dir
+	cf.cursor = 0
+	cf.offset = 0
+	cf.err = nil
+	cf.refresh()
+	if _, ok := cf.children[dir]; ok {
+		return nil
+	}
+
+	cf.loading[dir] = true
+	return cf.listDir(dir, dockerClient)
+}
+
+// reload lists dir again, keeping the expanded directories.
+func (cf *ContainerFiles) reload(dir string, dockerClient *docker.Docker) tea.Cmd {
+	if _, ok := cf.children[dir]; !ok {
+		return nil
+	}
+
+	cf.loading[dir] = true
+	return cf.listDir(dir, dockerClient)
+}
+
+func (cf *ContainerFiles) refresh() {
+	cf.rows = visibleFiles(cf.root, cf.children, cf.expanded, 0)
+	if cf.cursor >= len(cf.rows) {
+		cf.cursor = len(cf.rows) - 1
+	}
+	if cf.cursor < 0 {
+		cf.cursor = 0
+	}
+}
+
+// visibleFiles flattens the tree under dir, including the children of the expanded directories.
+func visibleFiles(dir string, children map[string][]docker.ContainerFile, expanded map[string]bool, depth int) []fileRow {
+	rows := []fileRow{}
+	for _, f := range children[dir] {
+		rows = append(rows, fileRow{file: f, depth: depth})
+		if f.IsDir() && expanded[f.Path] {
+			rows = append(rows, visibleFiles(f.Path, children, expanded, depth+1)...)
+		}
+	}
+	return rows
+}
+
+func (cf ContainerFiles) selected() (docker.ContainerFile, bool) {
+	if cf.cursor >= len(cf.rows) {
+		return docker.ContainerFile{}, false
+	}
+	return cf.rows[cf.cursor].file, true
+}
+
+// selectedDir returns the directory of the selected entry, used as the upload destination.
+func (cf ContainerFiles) selectedDir() string {
+	f, ok := cf.selected()
+	switch {
+	case !ok:
+		return cf.root
+	case f.IsDir():
+		return f.Path
+	default:
+		return path.Dir(f.Path)
+	}
+}
+
+func (cf ContainerFiles) Update(msg tea.Msg, m *model) (ContainerFiles, tea.Cmd) {
+	switch msg := msg.(type) {
+	case containerFilesMsg:
+		if msg.containerID != cf.containerID {
+			return cf, nil
+		}
+
+		delete(cf.loading, msg.dir)
+		if msg.err != nil {
+			cf.err = msg.err
+			return cf, nil
+		}
+		cf.children[msg.dir] = msg.files
+		cf.refresh()
+		return cf, nil
+
+	case containerLinkMsg:
+		if msg.containerID != cf.containerID {
+			return cf, nil
+		}
+
+		if msg.err != nil {
+			cf.err = msg.err
+			return cf, nil
+		}
+		if msg.target.IsDir() {
+			return cf, cf.open(msg.target.Path, m.dockerClient)
+		}
+
+		m.containerFileView = NewContainerFileView(cf.containerID, msg.target)
+		m.currentModel = MContainerFileView
+		return cf, m.containerFileView.load(m.dockerClient)
+
+	case tea.KeyMsg:
+		if m.currentModel != MContainerFiles {
+			return cf, nil
+		}
+
+		var cmd tea.Cmd
+		switch msg.String() {
+		case "down":
+			if cf.cursor < len(cf.rows)-1 {
+				cf.cursor++
+			}
+		case "up":
+			if cf.cursor > 0 {
+				cf.cursor--
+			}
+		case "pgdown":
+			cf.cursor = min(cf.cursor+filesHeight, len(cf.rows)-1)
+		case "pgup":
+			cf.cursor = max(cf.cursor-filesHeight, 0)
+		case "enter", "right":
+			f, ok := cf.selected()
+			if !ok {
+				break
+			}
+
+			switch {
+			case f.IsDir():
+				if cf.expanded[f.Path] {
+					if msg.String() == "enter" {
+						delete(cf.expanded, f.Path)
+						cf.refresh()
+					}
+					break
+				}
+
+				cf.expanded[f.Path] = true
+				cf.refresh()
+				if _, ok := cf.children[f.Path]; !ok {
+					cf.loading[f.Path] = true
+					cmd = cf.listDir(f.Path, m.dockerClient)
+				}
+			case f.LinkTarget != "":
+				cmd = cf.resolveLink(f, m.dockerClient)
+			default:
+				m.containerFileView = NewContainerFileView(cf.containerID, f)
+				m.currentModel = MContainerFileView
+				cmd = m.containerFileView.load(m.dockerClient)
+			}
+		case "left":
+			f, ok := cf.selected()
+			if !ok {
+				break
+			}
+
+			if f.IsDir() && cf.expanded[f.Path] {
+				delete(cf.expanded, f.Path)
+				cf.refresh()
+				break
+			}
+			for i := cf.cursor - 1; i >= 0; i-- {
+				if cf.rows[i].depth < cf.rows[cf.cursor].depth {
+					cf.cursor = i
+					break
+				}
+			}
+		case "backspace":
+			if cf.root != "/" {
+				cmd = cf.open(path.Dir(cf.root), m.dockerClient)
+			}
+		case "ctrl+d":
+			if f, ok := cf.selected(); ok {
+				m.containerCopy = NewContainerDownload(cf.containerID, f.Path)
+				m.currentModel = MContainerCopy
+			}
+		case "ctrl+u":
+			m.containerCopy = NewContainerUpload(cf.containerID, cf.selectedDir())
+			m.currentModel = MContainerCopy
+		}
+
+		if cf.cursor < cf.offset {
+			cf.offset = cf.cursor
+		}
+		if cf.cursor >= cf.offset+filesHeight {
+			cf.offset = cf.cursor - filesHeight + 1
+		}
+		return cf, cmd
+	}
+
+	return cf, nil
+}
+
+func (cf ContainerFiles) resolveLink(f docker.ContainerFile, dockerClient *docker.Docker) tea.Cmd {
+	containerID := cf.containerID
+	return func() tea.Msg {
+		target, err := dockerClient.ContainerResolvePath(containerID, f.Path)
+		return containerLinkMsg{containerID: containerID, target: target, err: err}
+	}
+}
+
+func (cf ContainerFiles) View() string {
+	title := titleTableStyle(fmt.Sprintf("FILES %s:%s", cf.container, cf.root))
+	help := helpStyle("\n  ↑/↓: Navigate • enter/→: Expand directory or view file • ←: Collapse • backspace: Parent directory • ctrl+d: Download • ctrl+u: Upload • Esc: back\n")
+
+	lines := []string{}
+	for i := cf.offset; i < len(cf.rows) && i < cf.offset+filesHeight; i++ {
+		line := cf.renderRow(cf.rows[i])
+		if i == cf.cursor {
+			line = lipgloss.NewStyle().Foreground(lipgloss.Color("229")).Background(lipgloss.Color("57")).Render(line)
+		}
+		lines = append(lines, line)
+	}
+	if len(cf.rows) == 0 && !cf.loading[cf.root] {
+		lines = append(lines, "(empty directory)")
+	}
+
+	status := ""
+	if len(cf.loading) > 0 {
+		status = "\n  Loading..."
+	}
+	if cf.err != nil {
+		status = "\n  Error: " + cf.err.Error()
+	}
+
+	return title + "\n" + explorerStyle.Render(strings.Join(lines, "\n")) + status + help
+}
+
+func (cf ContainerFiles) renderRow(row fileRow) string {
+	f := row.file
+	name := f.Name
+	size := utils.FormatSize(f.Size)
+	marker := "  "
+	switch {
+	case f.IsDir():
+		name += "/"
+		size = ""
+		marker = "▸ "
+		if cf.expanded[f.Path] {
+			marker = "▾ "
+		}
+	case f.LinkTarget != "":
+		name += " -> " + f.LinkTarget
+		size = ""
+	}
+
+	return fmt.Sprintf("%-11s %10s  %s  %s%s%s", f.Mode.String(), size, f.ModTime.Local().Format("2006-01-02 15:04"), strings.Repeat("  ", row.depth), marker, name)
+}
@@ -0,0 +1,75 @@
+package models
+
+import (
+	"io/fs"
+	"reflect"
+	"testing"
+
+	"github.com/ernesto27/dcli/docker"
+)
+
+func TestVisibleFiles(t *testing.T) {
+	children := map[string][]docker.ContainerFile{
+		"/": {
+			{Name: "etc", Path: "/etc", Mode: fs.ModeDir | 0755},
+			{Name: "usr", Path: "/usr", Mode: fs.ModeDir | 0755},
+			{Name: "hosts", Path: "/hosts", Mode: 0644},
+		},
+		"/etc": {
+			{Name: "nginx", Path: "/etc/nginx", Mode: fs.ModeDir | 0755},
+			{Name: "passwd", Path: "/etc/passwd", Mode: 0644},
+		},
+		"/usr": {
+			{Name: "bin", Path: "/usr/bin", Mode: fs.ModeDir | 0755},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		expanded map[string]bool
+		want     []string
+	}{
+		{
+			name:     "should list only the root when nothing is expanded",
+			expanded: map[string]bool{},
+			want:     []string{"0 /etc", "0 /usr", "0 /hosts"},
+		},
+		{
+			name:     "should include the children of expanded directories",
+			expanded: map[string]bool{"/etc": true, "/etc/nginx": true},
+			want:     []string{"0 /etc", "1 /etc/nginx", "1 /etc/passwd", "0 /usr", "0 /hosts"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := []string{}
+			for _, row := range visibleFiles("/", children, tt.expanded, 0) {
+				got = append(got, string(rune('0'+row.depth))+" "+row.file.Path)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("visibleFiles() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsBinary(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+		want bool
+	}{
+		{name: "text", data: []byte("server {\n  listen 80;\n}\n"), want: false},
+		{name: "empty", data: []byte{}, want: false},
+		{name: "binary", data: []byte{0x7f, 'E', 'L', 'F', 0, 1}, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isBinary(tt.data); got != tt.want {
+				t.Errorf("isBinary() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package models
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+var killSignals = []string{"SIGTERM", "SIGKILL", "SIGHUP", "SIGINT", "SIGQUIT", "SIGUSR1", "SIGUSR2"}
+
+type ContainerKill struct {
+	Options
+	containerID string
+}
+
+func NewContainerKill(containerID string, container string) ContainerKill {
+	return ContainerKill{
+		Options: Options{
+			Cursor:  0,
+			Choice:  "",
+			Choices: killSignals,
+			Text1:   container,
+		},
+		containerID: containerID,
+	}
+}
+
+func (o ContainerKill) View() string {
+	title := fmt.Sprintf("Send signal to container: %s", o.Text1)
+	return o.Options.View(title)
+}
+
+func (o ContainerKill) Update(msg tea.Msg, m *model) (ContainerKill, tea.Cmd) {
+	if m.currentModel != MContainerKill {
+		return o, nil
+	}
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "enter":
+			if err := m.dockerClient.ContainerKill(o.containerID, o.Choices[o.Cursor]); err != nil {
+				o.MessageError = err.Error()
+				return o, nil
+			}
+
+			m.setContainerList()
+			return o, tea.ClearScreen
+		case "down":
+			o.Cursor++
+			if o.Cursor >= len(o.Choices) {
+				o.Cursor = 0
+			}
+		case "up":
+			o.Cursor--
+			if o.Cursor < 0 {
+				o.Cursor = len(o.Choices) - 1
+			}
+		}
+	}
+
+	return o, nil
+}
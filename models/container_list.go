@@ -29,12 +29,14 @@ var orderDescContainer bool
 func NewContainerList(rows []table.Row) ContainerList {
 	columns := []table.Column{
 		{Title: "ID", Width: 20},
-		{Title: "Container", Width: 30},
-		{Title: "Image", Width: 30},
-		{Title: "Port", Width: 6},
+		{Title: "Container", Width: 25},
+		{Title: "Image", Width: 24},
+		{Title: "Port", Width: 10},
 		{Title: "Url", Width: 20},
-		{Title: "Size", Width: 20},
-		{Title: "Status", Width: 20},
+		{Title: "Size", Width: 12},
+		// an arrow and a flag are coloured, like "↓ restarting crash loop"
+		{Title: "Status", Width: 23 + 2*ansiWidth},
+		{Title: "Health", Width: 9 + ansiWidth},
 	}
 
 	t := table.New(
@@ -95,7 +97,16 @@ func (cl ContainerList) Update(msg tea.Msg, m *model) (table.Model, tea.Cmd) {
 			m.containerSearch.textInput.SetValue("")
 			m.currentModel = MContainerSearch
 		case "ctrl+o":
-			ov := NewContainerOptions(m.containerList.table.SelectedRow()[1], m.containerList.table.SelectedRow()[2])
+			if len(m.containerList.table.SelectedRow()) == 0 {
+				return cl.table, nil
+			}
+
+			container, err := m.dockerClient.GetContainerByName(m.containerList.table.SelectedRow()[1])
+			if err != nil {
+				fmt.Println(err)
+			}
+
+			ov := NewContainerOptions(m.containerList.table.SelectedRow()[1], m.containerList.table.SelectedRow()[2], container.State)
 			m.containerOptions = ov
 			m.currentModel = MContainerOptions
 			m.ContainerID = m.containerList.table.SelectedRow()[0]
@@ -141,10 +152,67 @@ func (cl ContainerList) Update(msg tea.Msg, m *model) (table.Model, tea.Cmd) {
 			m.currentModel = MContainerStats
 		case "ctrl+e":
 			m.currentModel = MContainerExecOptions
-			m.containerExecOptions = NewContainerExecOptions(m.containerList.table.SelectedRow()[1])
+			m.containerExecOptions = NewContainerExecOptions(m.containerList.table.SelectedRow()[0], m.containerList.table.SelectedRow()[1])
+			return cl.table, m.containerExecOptions.detectShells(m.dockerClient)
+		case "ctrl+g":
+			if len(m.containerList.table.SelectedRow()) == 0 {
+				return cl.table, nil
+			}
+
+			m.containerFiles = NewContainerFiles(m.containerList.table.SelectedRow()[0], m.containerList.table.SelectedRow()[1])
+			m.currentModel = MContainerFiles
+			return cl.table, m.containerFiles.load(m.dockerClient)
+		case "ctrl+w":
+			if len(m.containerList.table.SelectedRow()) == 0 {
+				return cl.table, nil
+			}
+
+			container, err := m.dockerClient.GetContainerByName(m.containerList.table.SelectedRow()[1])
+			if err != nil {
+				fmt.Println(err)
+			}
+
+			urls := docker.PublishedURLs(container.Ports)
+			var openErr error
+			if len(urls) == 1 {
+				if openErr = utils.OpenURL(urls[0]); openErr == nil {
+					return cl.table, nil
+				}
+			}
+
+			items := []valueItem{}
+			for _, url := range urls {
+				items = append(items, valueItem{label: "URL", value: url})
+			}
+			m.valueOptions = NewValueOptions(container.Name, items, true, MContainerList)
+			switch {
+			case len(urls) == 0:
+				m.valueOptions.MessageError = "The container has no published tcp ports"
+			case openErr != nil:
+				m.valueOptions.MessageError = fmt.Sprintf("%s, open %s manually", openErr.Error(), urls[0])
+			}
+			m.currentModel = MValueOptions
+		case "ctrl+y":
+			if len(m.containerList.table.SelectedRow()) == 0 {
+				return cl.table, nil
+			}
+
+			container, err := m.dockerClient.GetContainerByName(m.containerList.table.SelectedRow()[1])
+			if err != nil {
+				fmt.Println(err)
+			}
+
+			m.valueOptions = NewValueOptions(container.Name, containerValues(container), false, MContainerList)
+			m.currentModel = MValueOptions
+		case "ctrl+u":
+			m.unhealthyOnly = !m.unhealthyOnly
+			m.setContainerList()
 		case "ctrl+a":
 			orderDescContainer = !orderDescContainer
 			containers := m.dockerClient.GetContainersOrderBySize(orderDescContainer)
+			if m.unhealthyOnly {
+				containers = filterUnhealthy(containers)
+			}
 			cl.table.SetRows(GetContainerRows(containers, ""))
 		case "ctrl+t":
 			top, err := m.dockerClient.GetContainerTop(m.containerList.table.SelectedRow()[0])
@@ -181,9 +249,14 @@ func GetContainerRows(containerList []docker.MyContainer, query string) []table.
 	for _, c := range filtered {
 		var port string
 		var url string
-		if len(c.Ports) > 0 {
-			url = fmt.Sprintf("http://%s:%d", "localhost", c.Ports[0].PublicPort)
-			port = fmt.Sprintf("%d", c.Ports[0].PublicPort)
+		if published := docker.PublishedPorts(c.Ports); len(published) > 0 {
+			port = fmt.Sprintf("%d", published[0].PublicPort)
+			if len(published) > 1 {
+				port += fmt.Sprintf(" +%d", len(published)-1)
+			}
+		}
+		if urls := docker.PublishedURLs(c.Ports); len(urls) > 0 {
+			url = urls[0]
 		}
 
 		up := "\u2191"
@@ -197,9 +270,48 @@ func GetContainerRows(containerList []docker.MyContainer, query string) []table.
 			currState = greenUpArrow + " " + c.State
 		}
 
-		item := []string{c.ID, c.Name, c.Image, port, url, c.Size, currState}
+		if flags := containerFlags(c); flags != "" {
+			currState += " \033[31m" + flags + "\033[0m"
+		}
+
+		item := []string{c.ID, c.Name, c.Image, port, url, c.Size, currState, formatHealth(c.HealthStatus())}
 		rowsItems = append(rowsItems, item)
 	}
 
 	return rowsItems
 }
+
+// containerFlags warns about containers crash looping or killed because they ran out of memory.
+func containerFlags(c docker.MyContainer) string {
+	switch {
+	case c.CrashLoop:
+		return "crash loop"
+	case c.OOMKilled:
+		return "OOM killed"
+	case c.State == exited && c.ExitCode == 137:
+		return "killed"
+	}
+	return ""
+}
+
+func formatHealth(status string) string {
+	switch status {
+	case "healthy":
+		return "\033[32m" + status + "\033[0m"
+	case "starting":
+		return "\033[33m" + status + "\033[0m"
+	case "unhealthy":
+		return "\033[31m" + status + "\033[0m"
+	}
+	return status
+}
+
+func filterUnhealthy(containers []docker.MyContainer) []docker.MyContainer {
+	filtered := []docker.MyContainer{}
+	for _, c := range containers {
+		if c.HealthStatus() == "unhealthy" {
+			filtered = append(filtered, c)
+		}
+	}
+	return filtered
+}
@@ -2,11 +2,13 @@ package models
 
 import (
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/ernesto27/dcli/docker"
 
 	"github.com/charmbracelet/bubbles/table"
+	"github.com/docker/docker/api/types"
 )
 
 func TestGetContainerRows(t *testing.T) {
@@ -38,7 +40,7 @@ func TestGetContainerRows(t *testing.T) {
 				},
 				query: "",
 			},
-			want: []table.Row{{"1234567890", "test", "test", "", "", "", "\033[32m\u2191\033[0m " + running}, {"12345678902", "test2", "test2", "", "", "", "\033[31m\u2193\033[0m " + exited}},
+			want: []table.Row{{"1234567890", "test", "test", "", "", "", "\033[32m\u2191\033[0m " + running, ""}, {"12345678902", "test2", "test2", "", "", "", "\033[31m\u2193\033[0m " + exited, ""}},
 		},
 		{
 			name: "should get filtered results when search by name",
@@ -59,7 +61,7 @@ func TestGetContainerRows(t *testing.T) {
 				},
 				query: "nginx",
 			},
-			want: []table.Row{{"1234567890", "nginx", "test", "", "", "", "\033[32m\u2191\033[0m " + running}},
+			want: []table.Row{{"1234567890", "nginx", "test", "", "", "", "\033[32m\u2191\033[0m " + running, ""}},
 		},
 		{
 			name: "should get filtered results when search by image",
@@ -80,7 +82,44 @@ func TestGetContainerRows(t *testing.T) {
 				},
 				query: "mysq",
 			},
-			want: []table.Row{{"1234567890", "test", "mysql", "", "", "", "\033[32m\u2191\033[0m " + running}},
+			want: []table.Row{{"1234567890", "test", "mysql", "", "", "", "\033[32m\u2191\033[0m " + running, ""}},
+		},
+		{
+			name: "should summarise the published ports",
+			args: args{
+				containers: []docker.MyContainer{
+					{
+						ID:    "1234567890",
+						Name:  "web",
+						Image: "nginx",
+						State: running,
+						Ports: []types.Port{
+							{PrivatePort: 53, PublicPort: 5353, Type: "udp"},
+							{IP: "0.0.0.0", PrivatePort: 80, PublicPort: 8080, Type: "tcp"},
+							{IP: "::", PrivatePort: 80, PublicPort: 8080, Type: "tcp"},
+							{PrivatePort: 9000, Type: "tcp"},
+						},
+					},
+				},
+				query: "",
+			},
+			want: []table.Row{{"1234567890", "web", "nginx", "8080 +1", "http://localhost:8080", "", "\033[32m\u2191\033[0m " + running, ""}},
+		},
+		{
+			name: "should show the health status",
+			args: args{
+				containers: []docker.MyContainer{
+					{
+						ID:     "1234567890",
+						Name:   "web",
+						Image:  "nginx",
+						State:  running,
+						Health: &types.Health{Status: "unhealthy"},
+					},
+				},
+				query: "",
+			},
+			want: []table.Row{{"1234567890", "web", "nginx", "", "", "", "\033[32m\u2191\033[0m " + running, "\033[31munhealthy\033[0m"}},
 		},
 	}
 
@@ -100,3 +139,63 @@ func TestGetContainerRows(t *testing.T) {
 	}
 
 }
+
+func TestFilterUnhealthy(t *testing.T) {
+	containers := []docker.MyContainer{
+		{Name: "web", Health: &types.Health{Status: "unhealthy"}},
+		{Name: "db", Health: &types.Health{Status: "healthy"}},
+		{Name: "cache"},
+	}
+
+	got := filterUnhealthy(containers)
+	if len(got) != 1 || got[0].Name != "web" {
+		t.Errorf("filterUnhealthy() = %v, want only web", got)
+	}
+}
+
+func TestContainerFlags(t *testing.T) {
+	tests := []struct {
+		name      string
+		container docker.MyContainer
+		want      string
+	}{
+		{name: "running", container: docker.MyContainer{State: running}, want: ""},
+		{name: "crash loop", container: docker.MyContainer{State: "restarting", CrashLoop: true, OOMKilled: true}, want: "crash loop"},
+		{name: "oom killed", container: docker.MyContainer{State: exited, ExitCode: 137, OOMKilled: true}, want: "OOM killed"},
+		{name: "killed", container: docker.MyContainer{State: exited, ExitCode: 137}, want: "killed"},
+		{name: "exited with error", container: docker.MyContainer{State: exited, ExitCode: 1}, want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := containerFlags(tt.container); got != tt.want {
+				t.Errorf("containerFlags() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestContainerListHealth(t *testing.T) {
+	containers := []docker.MyContainer{
+		{ID: "1", Name: "web", State: running, Health: &types.Health{Status: "unhealthy"}},
+	}
+
+	view := NewContainerList(GetContainerRows(containers, "")).table.View()
+	if !strings.Contains(view, "\033[31munhealthy\033[0m") {
+		t.Errorf("NewContainerList().table.View() = %q, want the health status with its reset code", view)
+	}
+}
+
+func TestContainerListStatus(t *testing.T) {
+	containers := []docker.MyContainer{
+		{ID: "1", Name: "web", State: "restarting", CrashLoop: true, Health: &types.Health{Status: "unhealthy"}},
+	}
+
+	view := NewContainerList(GetContainerRows(containers, "")).table.View()
+	if !strings.Contains(view, "\033[31m\u2193\033[0m restarting \033[31mcrash loop\033[0m") {
+		t.Errorf("NewContainerList().table.View() = %q, want the status with its reset codes", view)
+	}
+	if !strings.Contains(view, "\033[31munhealthy\033[0m") {
+		t.Errorf("NewContainerList().table.View() = %q, want the health status with its reset code", view)
+	}
+}
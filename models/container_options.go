@@ -2,7 +2,6 @@ package models
 
 import (
 	"fmt"
-	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 )
@@ -11,20 +10,75 @@ type ContainerOptions struct {
 	Options
 }
 
-func NewContainerOptions(container string, image string) ContainerOptions {
-	choices := []string{Stop, Start, Remove, Restart, Pause, Unpause}
+var containerChoices = []string{Stop, Start, Remove, Restart, Pause, Unpause, Kill, Rename, Attach, Commit, Export, Resources}
 
+func NewContainerOptions(container string, image string, state string) ContainerOptions {
 	return ContainerOptions{
 		Options{
-			Cursor:  0,
-			Choice:  "",
-			Choices: choices,
-			Text1:   container,
-			Text2:   image,
+			Cursor:   0,
+			Choice:   "",
+			Choices:  containerChoices,
+			Text1:    container,
+			Text2:    image,
+			Disabled: containerDisabledChoices(state),
 		},
 	}
 }
 
+// containerDisabledChoices returns the actions that docker rejects for a container in the given state.
+func containerDisabledChoices(state string) map[string]string {
+	switch state {
+	case "running":
+		return map[string]string{
+			Start:   "the container is already running",
+			Unpause: "the container is not paused",
+		}
+	case "paused":
+		return map[string]string{
+			Start:  "the container is paused, unpause it",
+			Pause:  "the container is already paused",
+			Attach: "the container is paused, unpause it first",
+		}
+	case "restarting":
+		return map[string]string{
+			Start:   "the container is restarting",
+			Pause:   "the container is restarting",
+			Unpause: "the container is not paused",
+			Attach:  "the container is restarting",
+			Commit:  "the container is restarting",
+		}
+	case "removing":
+		disabled := map[string]string{}
+		for _, choice := range containerChoices {
+			disabled[choice] = "the container is being removed"
+		}
+		return disabled
+	case "dead":
+		return map[string]string{
+			Stop:      "the container is not running",
+			Start:     "dead containers can only be removed",
+			Restart:   "dead containers can only be removed",
+			Pause:     "the container is not running",
+			Unpause:   "the container is not paused",
+			Kill:      "the container is not running",
+			Rename:    "dead containers can only be removed",
+			Attach:    "the container is not running",
+			Commit:    "dead containers can only be removed",
+			Export:    "dead containers can only be removed",
+			Resources: "dead containers can only be removed",
+		}
+	default:
+		// created and exited
+		return map[string]string{
+			Stop:    "the container is not running",
+			Pause:   "the container is not running",
+			Unpause: "the container is not paused",
+			Kill:    "the container is not running",
+			Attach:  "the container is not running, start it first",
+		}
+	}
+}
+
 func (o ContainerOptions) View() string {
 	title := fmt.Sprintf("Options container: %s - %s", o.Text1, o.Text2)
 	return o.Options.View(title)
@@ -43,53 +97,92 @@ func (o ContainerOptions) Update(msg tea.Msg, m *model) (ContainerOptions, tea.C
 				return o, nil
 			}
 
-			errAction := false
+			if reason := o.disabledReason(); reason != "" {
+				o.MessageError = reason
+				return o, nil
+			}
+
 			switch m.containerOptions.Choices[m.containerOptions.Cursor] {
-			case Stop:
-				err := m.dockerClient.ContainerStop(m.ContainerID)
+			case Attach:
+				ca, cmd, err := NewContainerAttach(m.dockerClient, m.ContainerID, o.Text1)
 				if err != nil {
-					fmt.Println(err)
-					errAction = true
+					o.MessageError = err.Error()
+					return o, nil
 				}
-				time.Sleep(1 * time.Second)
+
+				m.containerAttach = ca
+				m.currentModel = MContainerAttach
+				return o, cmd
+			case Commit:
+				m.containerCommit = NewContainerCommit(m.ContainerID, o.Text1)
+				m.currentModel = MContainerCommit
+				return o, nil
+			case Export:
+				container, err := m.dockerClient.GetContainerByName(o.Text1)
+				if err != nil {
+					o.MessageError = err.Error()
+					return o, nil
+				}
+
+				m.containerExport = NewContainerExport(m.ContainerID, o.Text1, container.SizeOriginal)
+				m.currentModel = MContainerExport
+				return o, nil
+			case Resources:
+				hostConfig, err := m.dockerClient.ContainerHostConfig(m.ContainerID)
+				if err != nil {
+					o.MessageError = err.Error()
+					return o, nil
+				}
+
+				m.containerResources = NewContainerResources(m.ContainerID, o.Text1, hostConfig)
+				m.currentModel = MContainerResources
+				return o, nil
+			case Stop:
+				m.containerStop = NewContainerStop(m.ContainerID, o.Text1, m.dockerClient.ContainerStopTimeout(m.ContainerID))
+				m.currentModel = MContainerStop
+				return o, nil
+			case Kill:
+				m.containerKill = NewContainerKill(m.ContainerID, o.Text1)
+				m.currentModel = MContainerKill
+				return o, nil
+			case Rename:
+				m.containerRename = NewContainerRename(m.ContainerID, o.Text1)
+				m.currentModel = MContainerRename
+				return o, nil
 			case Start:
 				err := m.dockerClient.ContainerStart(m.ContainerID)
 				if err != nil {
-					fmt.Println(err)
-					errAction = true
+					o.MessageError = err.Error()
+					return o, nil
 				}
 			case Remove:
 				err := m.dockerClient.ContainerRemove(m.ContainerID)
 				if err != nil {
-					fmt.Println(err)
-					errAction = true
+					o.MessageError = err.Error()
+					return o, nil
 				}
 			case Restart:
 				err := m.dockerClient.ContainerRestart(m.ContainerID)
 				if err != nil {
-					fmt.Println(err)
-					errAction = true
+					o.MessageError = err.Error()
+					return o, nil
 				}
 			case Pause:
 				err := m.dockerClient.ContainerPause(m.ContainerID)
 				if err != nil {
-					fmt.Println(err)
-					errAction = true
+					o.MessageError = err.Error()
+					return o, nil
 				}
 			case Unpause:
 				err := m.dockerClient.ContainerUnpause(m.ContainerID)
 				if err != nil {
-					fmt.Println(err)
-					errAction = true
+					o.MessageError = err.Error()
+					return o, nil
 				}
 			}
 
-			if !errAction {
-				m.setContainerList()
-				m.currentModel = MContainerList
-				return o, tea.ClearScreen
-			}
-
+			m.setContainerList()
+			return o, tea.ClearScreen
 		}
 	}
 
@@ -0,0 +1,88 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/ernesto27/dcli/docker"
+)
+
+func TestContainerDisabledChoices(t *testing.T) {
+	tests := []struct {
+		state    string
+		enabled  []string
+		disabled []string
+	}{
+		{state: "running", enabled: []string{Stop, Pause, Kill, Attach, Remove}, disabled: []string{Start, Unpause}},
+		{state: "paused", enabled: []string{Unpause, Stop, Kill}, disabled: []string{Start, Pause, Attach}},
+		{state: "exited", enabled: []string{Start, Remove, Rename, Commit}, disabled: []string{Stop, Pause, Unpause, Kill, Attach}},
+		{state: "created", enabled: []string{Start, Remove}, disabled: []string{Stop, Kill}},
+		{state: "dead", enabled: []string{Remove}, disabled: []string{Start, Restart, Rename, Commit}},
+		{state: "removing", disabled: containerChoices},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.state, func(t *testing.T) {
+			got := containerDisabledChoices(tt.state)
+			for _, choice := range tt.enabled {
+				if reason, ok := got[choice]; ok {
+					t.Errorf("containerDisabledChoices() %s disabled with %q, want enabled", choice, reason)
+				}
+			}
+			for _, choice := range tt.disabled {
+				if _, ok := got[choice]; !ok {
+					t.Errorf("containerDisabledChoices() %s enabled, want disabled", choice)
+				}
+			}
+		})
+	}
+}
+
+func TestImageDisabledChoices(t *testing.T) {
+	tests := []struct {
+		name       string
+		tags       []string
+		containers []docker.MyContainer
+		disabled   []string
+	}{
+		{
+			name:     "should disable push and untag without tags",
+			disabled: []string{Push, Untag},
+		},
+		{
+			name:     "should disable untag with one tag",
+			tags:     []string{"nginx:latest"},
+			disabled: []string{Untag},
+		},
+		{
+			name:     "should disable remove with several tags",
+			tags:     []string{"nginx:latest", "nginx:1.25"},
+			disabled: []string{Remove},
+		},
+		{
+			name:       "should disable remove when used by a stopped container",
+			tags:       []string{"nginx:latest", "nginx:1.25"},
+			containers: []docker.MyContainer{{Name: "web", State: "exited"}},
+			disabled:   []string{Remove},
+		},
+		{
+			name:       "should disable force remove when used by a running container",
+			tags:       []string{"nginx:latest", "nginx:1.25"},
+			containers: []docker.MyContainer{{Name: "web", State: "running"}},
+			disabled:   []string{Remove, ForceRemove},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := imageDisabledChoices(tt.tags, tt.containers)
+			if len(got) != len(tt.disabled) {
+				t.Errorf("imageDisabledChoices() = %v, want %v disabled", got, tt.disabled)
+			}
+			for _, choice := range tt.disabled {
+				if _, ok := got[choice]; !ok {
+					t.Errorf("imageDisabledChoices() %s enabled, want disabled", choice)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package models
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type ContainerRename struct {
+	Form
+	containerID string
+	container   string
+}
+
+func NewContainerRename(containerID string, container string) ContainerRename {
+	return ContainerRename{
+		Form: NewForm([]FormField{
+			{Label: "New name", Placeholder: "web-1", Value: container},
+		}),
+		containerID: containerID,
+		container:   container,
+	}
+}
+
+func (cr ContainerRename) View() string {
+	title := fmt.Sprintf("Rename container: %s", cr.container)
+	return cr.Form.View(title)
+}
+
+func (cr ContainerRename) Update(msg tea.Msg, m *model) (ContainerRename, tea.Cmd) {
+	if m.currentModel != MContainerRename {
+		return cr, nil
+	}
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "enter":
+			name := cr.Value(0)
+			if name == "" {
+				cr.MessageError = "name is required"
+				return cr, nil
+			}
+
+			if err := m.dockerClient.ContainerRename(cr.containerID, name); err != nil {
+				cr.MessageError = err.Error()
+				return cr, nil
+			}
+
+			m.setContainerList()
+			return cr, tea.ClearScreen
+		}
+	}
+
+	var cmd tea.Cmd
+	cr.Form, cmd = cr.Form.Update(msg)
+	return cr, cmd
+}
@@ -0,0 +1,228 @@
+package models
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ernesto27/dcli/docker"
+	"github.com/ernesto27/dcli/utils"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/go-units"
+)
+
+type ContainerResources struct {
+	Form
+	containerID string
+	container   string
+}
+
+func NewContainerResources(containerID string, name string, hostConfig *container.HostConfig) ContainerResources {
+	return ContainerResources{
+		Form: NewForm([]FormField{
+			{Label: "CPU shares (relative weight)", Placeholder: "1024", Value: formatInt(hostConfig.CPUShares)},
+			{Label: "CPU period (microseconds)", Placeholder: "100000", Value: formatInt(hostConfig.CPUPeriod)},
+			{Label: "CPU quota (microseconds per period)", Placeholder: "50000", Value: formatInt(hostConfig.CPUQuota)},
+			{Label: "Memory limit", Placeholder: "512m", Value: formatMemory(hostConfig.Memory)},
+			{Label: "Memory + swap limit (-1 for unlimited swap)", Placeholder: "1g", Value: formatMemory(hostConfig.MemorySwap)},
+			{Label: "PIDs limit (-1 for unlimited)", Placeholder: "200", Value: formatPidsLimit(hostConfig.PidsLimit)},
+			{Label: "Restart policy (no, always, unless-stopped, on-failure[:max retries])", Placeholder: "unless-stopped", Value: formatRestartPolicy(hostConfig.RestartPolicy)},
+		}),
+		containerID: containerID,
+		container:   name,
+	}
+}
+
+func (cr ContainerResources) View() string {
+	title := fmt.Sprintf("Resources container: %s", cr.container)
+	return cr.Form.View(title) + "\n" + helpStyle("Empty values keep the current limit, the changes are applied without recreating the container") + "\n"
+}
+
+func (cr ContainerResources) Update(msg tea.Msg, m *model) (ContainerResources, tea.Cmd) {
+	if m.currentModel != MContainerResources {
+		return cr, nil
+	}
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "enter":
+			resources, err := cr.resources()
+			if err != nil {
+				cr.MessageError = err.Error()
+				return cr, nil
+			}
+
+			restartPolicy, err := parseRestartPolicy(cr.Value(6))
+			if err != nil {
+				cr.MessageError = err.Error()
+				return cr, nil
+			}
+
+			containerID := cr.containerID
+			updates := updateContainer(m.dockerClient, containerID, cr.container, resources, restartPolicy)
+			return cr, m.startProgress("Update resources of "+cr.container, MContainerStats, updates, func(m *model) tea.Cmd {
+				stats, err := m.dockerClient.ContainerStats(containerID)
+				if err != nil {
+					fmt.Println(err)
+				}
+
+				cs, err := NewContainerStats(stats, utils.CreateTable)
+				if err != nil {
+					fmt.Println(err)
+				}
+				m.containerStats = cs
+				return nil
+			})
+		}
+	}
+
+	var cmd tea.Cmd
+	cr.Form, cmd = cr.Form.Update(msg)
+	return cr, cmd
+}
+
+// updateContainer applies the limits and restart policy in the background, the warnings of docker are shown as progress.
+func updateContainer(dockerClient *docker.Docker, containerID string, name string, resources container.Resources, restartPolicy container.RestartPolicy) <-chan progressUpdate {
+	updates := make(chan progressUpdate)
+
+	go func() {
+		defer close(updates)
+
+		updates <- progressUpdate{text: "Updating " + name}
+		warnings, err := dockerClient.ContainerUpdate(containerID, resources, restartPolicy)
+		for _, w := range warnings {
+			updates <- progressUpdate{text: "Warning: " + w}
+		}
+		if err != nil {
+			updates <- progressUpdate{err: err}
+			return
+		}
+		updates <- progressUpdate{text: "Updated " + name}
+	}()
+
+	return updates
+}
+
+func (cr ContainerResources) resources() (container.Resources, error) {
+	resources := container.Resources{}
+	var err error
+
+	if resources.CPUShares, err = parseInt("CPU shares", cr.Value(0)); err != nil {
+		return resources, err
+	}
+	if resources.CPUPeriod, err = parseInt("CPU period", cr.Value(1)); err != nil {
+		return resources, err
+	}
+	if resources.CPUQuota, err = parseInt("CPU quota", cr.Value(2)); err != nil {
+		return resources, err
+	}
+	if resources.Memory, err = parseMemory("memory limit", cr.Value(3)); err != nil {
+		return resources, err
+	}
+	if resources.MemorySwap, err = parseMemory("memory + swap limit", cr.Value(4)); err != nil {
+		return resources, err
+	}
+
+	if cr.Value(5) != "" {
+		pids, err := parseInt("PIDs limit", cr.Value(5))
+		if err != nil {
+			return resources, err
+		}
+		resources.PidsLimit = &pids
+	}
+
+	return resources, nil
+}
+
+func formatInt(value int64) string {
+	if value == 0 {
+		return ""
+	}
+	return strconv.FormatInt(value, 10)
+}
+
+func parseInt(label string, value string) (int64, error) {
+	if value == "" {
+		return 0, nil
+	}
+
+	n, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s: %s", label, value)
+	}
+	return n, nil
+}
+
+// formatMemory formats a limit in bytes with the biggest unit that keeps it exact, like 512m.
+func formatMemory(value int64) string {
+	switch {
+	case value == 0:
+		return ""
+	case value < 0:
+		return strconv.FormatInt(value, 10)
+	case value%units.GiB == 0:
+		return fmt.Sprintf("%dg", value/units.GiB)
+	case value%units.MiB == 0:
+		return fmt.Sprintf("%dm", value/units.MiB)
+	case value%units.KiB == 0:
+		return fmt.Sprintf("%dk", value/units.KiB)
+	default:
+		return strconv.FormatInt(value, 10)
+	}
+}
+
+func parseMemory(label string, value string) (int64, error) {
+	switch value {
+	case "":
+		return 0, nil
+	case "-1":
+		return -1, nil
+	}
+
+	n, err := units.RAMInBytes(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s: %s", label, value)
+	}
+	return n, nil
+}
+
+func formatPidsLimit(value *int64) string {
+	if value == nil {
+		return ""
+	}
+	return formatInt(*value)
+}
+
+func formatRestartPolicy(policy container.RestartPolicy) string {
+	if policy.Name == "on-failure" && policy.MaximumRetryCount > 0 {
+		return fmt.Sprintf("%s:%d", policy.Name, policy.MaximumRetryCount)
+	}
+	return policy.Name
+}
+
+func parseRestartPolicy(value string) (container.RestartPolicy, error) {
+	name, retries, found := strings.Cut(value, ":")
+	policy := container.RestartPolicy{Name: name}
+
+	switch name {
+	case "", "no", "always", "unless-stopped":
+		if found {
+			return policy, fmt.Errorf("max retries is only allowed with on-failure")
+		}
+	case "on-failure":
+		if found {
+			n, err := strconv.Atoi(retries)
+			if err != nil || n < 0 {
+				return policy, fmt.Errorf("invalid max retries: %s", retries)
+			}
+			policy.MaximumRetryCount = n
+		}
+	default:
+		return policy, fmt.Errorf("invalid restart policy: %s", value)
+	}
+
+	return policy, nil
+}
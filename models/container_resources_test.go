@@ -0,0 +1,63 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types/container"
+)
+
+func TestFormatAndParseMemory(t *testing.T) {
+	tests := []struct {
+		value int64
+		want  string
+	}{
+		{value: 0, want: ""},
+		{value: -1, want: "-1"},
+		{value: 512 * 1024 * 1024, want: "512m"},
+		{value: 2 * 1024 * 1024 * 1024, want: "2g"},
+		{value: 1500, want: "1500"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			got := formatMemory(tt.value)
+			if got != tt.want {
+				t.Errorf("formatMemory() = %v, want %v", got, tt.want)
+			}
+
+			parsed, err := parseMemory("memory", got)
+			if err != nil || parsed != tt.value {
+				t.Errorf("parseMemory() = %v, %v, want %v", parsed, err, tt.value)
+			}
+		})
+	}
+}
+
+func TestParseRestartPolicy(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    container.RestartPolicy
+		wantErr bool
+	}{
+		{value: "", want: container.RestartPolicy{}},
+		{value: "unless-stopped", want: container.RestartPolicy{Name: "unless-stopped"}},
+		{value: "on-failure:3", want: container.RestartPolicy{Name: "on-failure", MaximumRetryCount: 3}},
+		{value: "always:3", wantErr: true},
+		{value: "sometimes", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := parseRestartPolicy(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseRestartPolicy() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("parseRestartPolicy() = %v, want %v", got, tt.want)
+			}
+			if !tt.wantErr && formatRestartPolicy(got) != tt.value {
+				t.Errorf("formatRestartPolicy() = %v, want %v", formatRestartPolicy(got), tt.value)
+			}
+		})
+	}
+}
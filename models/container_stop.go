@@ -0,0 +1,77 @@
+package models
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/ernesto27/dcli/docker"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type ContainerStop struct {
+	Form
+	containerID string
+	container   string
+}
+
+func NewContainerStop(containerID string, container string, timeout int) ContainerStop {
+	return ContainerStop{
+		Form: NewForm([]FormField{
+			{Label: "Timeout in seconds before the container is killed", Placeholder: "10", Value: strconv.Itoa(timeout)},
+		}),
+		containerID: containerID,
+		container:   container,
+	}
+}
+
+func (cs ContainerStop) View() string {
+	title := fmt.Sprintf("Stop container: %s", cs.container)
+	return cs.Form.View(title)
+}
+
+func (cs ContainerStop) Update(msg tea.Msg, m *model) (ContainerStop, tea.Cmd) {
+	if m.currentModel != MContainerStop {
+		return cs, nil
+	}
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "enter":
+			timeout, err := strconv.Atoi(cs.Value(0))
+			if err != nil || timeout < 0 {
+				cs.MessageError = "timeout must be a number of seconds"
+				return cs, nil
+			}
+
+			updates := stopContainer(m.dockerClient, cs.containerID, cs.container, timeout)
+			return cs, m.startProgress("Stop "+cs.container, MContainerList, updates, func(m *model) tea.Cmd {
+				m.refreshContainerList()
+				return nil
+			})
+		}
+	}
+
+	var cmd tea.Cmd
+	cs.Form, cmd = cs.Form.Update(msg)
+	return cs, cmd
+}
+
+// stopContainer stops the container in the background, docker waits up to timeout seconds before killing it.
+func stopContainer(dockerClient *docker.Docker, containerID string, container string, timeout int) <-chan progressUpdate {
+	updates := make(chan progressUpdate)
+
+	go func() {
+		defer close(updates)
+
+		updates <- progressUpdate{text: fmt.Sprintf("Stopping %s, killed after %d seconds", container, timeout)}
+		if err := dockerClient.ContainerStop(containerID, timeout); err != nil {
+			updates <- progressUpdate{err: err}
+			return
+		}
+		updates <- progressUpdate{text: "Stopped " + container}
+	}()
+
+	return updates
+}
@@ -0,0 +1,105 @@
+package models
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type FormField struct {
+	Label       string
+	Placeholder string
+	Value       string
+}
+
+type Form struct {
+	labels       []string
+	inputs       []textinput.Model
+	focused      int
+	MessageError string
+}
+
+func NewForm(fields []FormField) Form {
+	f := Form{}
+	for i, field := range fields {
+		ti := textinput.New()
+		ti.Placeholder = field.Placeholder
+		ti.CharLimit = 256
+		ti.Width = 50
+		ti.SetValue(field.Value)
+		if i == 0 {
+			ti.Focus()
+		}
+
+		f.labels = append(f.labels, field.Label)
+		f.inputs = append(f.inputs, ti)
+	}
+
+	return f
+}
+
+func (f Form) Value(index int) string {
+	return strings.TrimSpace(f.inputs[index].Value())
+}
+
+func (f *Form) SetValue(index int, value string) {
+	f.inputs[index].SetValue(value)
+}
+
+func (f Form) Update(msg tea.Msg) (Form, tea.Cmd) {
+	if len(f.inputs) == 0 {
+		return f, nil
+	}
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "tab", "down":
+			f.focus(f.focused + 1)
+			return f, nil
+		case "shift+tab", "up":
+			f.focus(f.focused - 1)
+			return f, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	f.inputs[f.focused], cmd = f.inputs[f.focused].Update(msg)
+	return f, cmd
+}
+
+func (f *Form) focus(index int) {
+	if index >= len(f.inputs) {
+		index = 0
+	}
+	if index < 0 {
+		index = len(f.inputs) - 1
+	}
+
+	f.inputs[f.focused].Blur()
+	f.focused = index
+	f.inputs[f.focused].Focus()
+}
+
+func (f Form) View(title string) string {
+	s := strings.Builder{}
+
+	var style = lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#FAFAFA")).
+		Background(lipgloss.Color("#3259A8")).
+		Padding(1).
+		MarginTop(1).
+		MarginBottom(1)
+
+	s.WriteString("\n")
+	for i, input := range f.inputs {
+		s.WriteString(f.labels[i] + "\n")
+		s.WriteString(input.View() + "\n\n")
+	}
+	s.WriteString("(tab: next field • enter: confirm • esc: go back)\n")
+
+	return style.Render(title) + s.String() + "\n" + f.MessageError
+}
@@ -2,19 +2,29 @@ package models
 
 import (
 	"fmt"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/ernesto27/dcli/utils"
 
 	"github.com/ernesto27/dcli/docker"
 
+	"github.com/docker/docker/api/types/container"
+
 	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/glamour"
 	"github.com/charmbracelet/lipgloss"
 )
 
-func NewImageDetail(image docker.MyImage, createTable utils.CreateTableFunc) (viewport.Model, error) {
-	content := getContentDetailImage(image)
+type ImageDetail struct {
+	viewport viewport.Model
+	image    docker.MyImage
+}
+
+func NewImageDetail(image docker.MyImage, relations docker.MyImageRelations, createTable utils.CreateTableFunc) (ImageDetail, error) {
+	content := getContentDetailImage(image) + getContentImageRelations(image, relations)
 	const width = 120
 
 	vp := viewport.New(width, 30)
@@ -28,56 +38,277 @@ func NewImageDetail(image docker.MyImage, createTable utils.CreateTableFunc) (vi
 		glamour.WithWordWrap(width),
 	)
 	if err != nil {
-		return viewport.Model{}, err
+		return ImageDetail{}, err
 	}
 
 	str, err := renderer.Render(content)
 	if err != nil {
-		return viewport.Model{}, err
+		return ImageDetail{}, err
 	}
 
 	vp.SetContent(str)
 
-	return vp, nil
+	return ImageDetail{viewport: vp, image: image}, nil
 }
 
+func (id ImageDetail) View() string {
+	return id.viewport.View() + helpStyle("\n  ↑/↓: Navigate • ctrl+o: Tag actions • ctrl+s: Scan packages and vulnerabilities • Esc: back to list\n")
+}
+
+func (id ImageDetail) Update(msg tea.Msg, m *model) (ImageDetail, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+s":
+			if m.currentModel == MImageDetail {
+				name := id.image.GetName()
+				if name == "<none>" {
+					name = id.image.GetShortID()
+				}
+				m.imageScan = NewImageScan(id.image.GetID(), name)
+				m.currentModel = MImageScan
+				return id, nil
+			}
+		case "ctrl+o":
+			if m.currentModel == MImageDetail {
+				tags := id.image.GetTags()
+				switch len(tags) {
+				case 0:
+					m.imageOptions = NewImageOptions(id.image.GetName(), id.image.GetID(), tags, m.dockerClient.GetImageRelations(id.image).Containers)
+					m.currentModel = MImageOptions
+				case 1:
+					m.imageTagActions = NewImageTagActions(tags[0], tags, m.dockerClient.GetImageRelations(id.image).Containers, MImageDetail)
+					m.currentModel = MImageTagActions
+				default:
+					m.imageTagOptions = NewImageTagOptions(Actions, id.image.GetName(), id.image.GetID(), tags, MImageDetail)
+					m.currentModel = MImageTagOptions
+				}
+				return id, nil
+			}
+		}
+	}
+
+	id.viewport, _ = id.viewport.Update(msg)
+	return id, nil
+}
+
+const detailValueWidth = 80
+
 func getContentDetailImage(image docker.MyImage) string {
 	response := ""
+	inspect := image.Inspect
 
-	response += utils.CreateTable("# Image detail", []string{"Type", "Value"},
-		[][]string{
-			{"ID", image.Summary.ID},
-			{"Name", image.Summary.RepoTags[0]},
-			{"Size", image.GetFormatSize()},
-			{"Created", image.GetFormatTimestamp()},
-			{"Build", image.Inspect.Os + " - " + image.Inspect.Architecture + " - Docker version " + image.Inspect.DockerVersion},
-		})
+	platform := inspect.Os + "/" + inspect.Architecture
+	if inspect.Variant != "" {
+		platform += "/" + inspect.Variant
+	}
+	if inspect.OsVersion != "" {
+		platform += " " + inspect.OsVersion
+	}
+
+	rows := [][]string{}
+	rows = appendDetailRow(rows, "ID", inspect.ID)
+	rows = appendDetailRow(rows, "Size", image.GetFormatSize())
+	rows = appendDetailRow(rows, "Created", image.GetFormatTimestamp())
+	rows = appendDetailRow(rows, "Platform", platform)
+	rows = appendDetailRow(rows, "Docker version", inspect.DockerVersion)
+	rows = appendDetailRow(rows, "Author", inspect.Author)
+	rows = appendDetailRow(rows, "Comment", inspect.Comment)
+	rows = appendDetailRow(rows, "Parent", inspect.Parent)
+
+	response += utils.CreateTable("# Image detail", []string{"Type", "Value"}, rows)
+	response += getContentImageTags(image)
 
-	cmd := ""
-	if len(image.Inspect.Config.Cmd) > 0 {
-		cmd = strings.Join(image.Inspect.Config.Cmd, " ")
+	config := inspect.Config
+	if config == nil {
+		config = &container.Config{}
 	}
 
-	response += utils.CreateTable("# Dockerfile details", []string{"Type", "Value"},
-		[][]string{
-			{"Author", image.Inspect.Author},
-			{"CMD", cmd},
-			{"Ports", fmt.Sprintf("%v", image.Inspect.Config.ExposedPorts)},
-			{"Envs", fmt.Sprintf("%v", image.Inspect.Config.Env)},
-		})
+	rows = [][]string{}
+	rows = appendDetailRow(rows, "User", config.User)
+	rows = appendDetailRow(rows, "Working dir", config.WorkingDir)
+	rows = appendDetailRow(rows, "Entrypoint", formatCommand(config.Entrypoint))
+	rows = appendDetailRow(rows, "CMD", formatCommand(config.Cmd))
+	rows = appendDetailRow(rows, "Shell", formatCommand(config.Shell))
+	rows = appendDetailRow(rows, "Stop signal", config.StopSignal)
+	if config.StopTimeout != nil {
+		rows = appendDetailRow(rows, "Stop timeout", fmt.Sprintf("%ds", *config.StopTimeout))
+	}
 
-	response += "# Docker hub image url \n " + utils.GetDockerHubURL(image.Summary.RepoTags[0])
+	ports := []string{}
+	for p := range config.ExposedPorts {
+		ports = append(ports, string(p))
+	}
+	sort.Strings(ports)
+	for _, p := range ports {
+		rows = appendDetailRow(rows, "Port", p)
+	}
 
+	volumes := []string{}
+	for v := range config.Volumes {
+		volumes = append(volumes, v)
+	}
+	sort.Strings(volumes)
+	for _, v := range volumes {
+		rows = appendDetailRow(rows, "Volume", v)
+	}
+
+	for _, o := range config.OnBuild {
+		rows = appendDetailRow(rows, "On build", o)
+	}
+
+	response += utils.CreateTable("# Dockerfile details", []string{"Type", "Value"}, rows)
+	response += getContentHealthcheck(config.Healthcheck)
+
+	rows = [][]string{}
+	for _, e := range config.Env {
+		name, value, _ := strings.Cut(e, "=")
+		rows = appendDetailRow(rows, name, value)
+	}
+	if len(rows) > 0 {
+		response += utils.CreateTable("# Environment", []string{"Name", "Value"}, rows)
+	}
+
+	labels := []string{}
+	for l := range config.Labels {
+		labels = append(labels, l)
+	}
+	sort.Strings(labels)
+	rows = [][]string{}
+	for _, l := range labels {
+		rows = appendDetailRow(rows, l, config.Labels[l])
+	}
+	if len(rows) > 0 {
+		response += utils.CreateTable("# Labels", []string{"Label", "Value"}, rows)
+	}
+
+	rows = [][]string{}
+	for _, l := range image.GetLayers() {
+		command := escapeTableCell(strings.Join(strings.Fields(l.CreatedBy), " "))
+		rows = append(rows, []string{utils.FormatSize(l.Size), command})
+	}
+
+	response += utils.CreateTable("\n\n# Image Layers \n", []string{"Size", "Layer"}, rows)
+	response += "\n\n"
+
+	return response
+}
+
+func getContentImageTags(image docker.MyImage) string {
 	rows := [][]string{}
-	for _, l := range image.History {
-		if l.CreatedBy == "" {
-			continue
+	for _, t := range image.GetTags() {
+		url := ""
+		if docker.IsDockerHubImage(t) {
+			url = utils.GetDockerHubURL(t)
 		}
-		rows = append(rows, []string{utils.TrimValue(l.CreatedBy, 40)})
+		rows = append(rows, []string{t, url})
+	}
+	if len(rows) == 0 {
+		rows = append(rows, []string{"<none>", ""})
 	}
+	response := utils.CreateTable("# Tags (ctrl+o: tag actions)", []string{"Tag", "Docker hub image url"}, rows)
 
-	response += utils.CreateTable("\n\n# Image Layers \n", []string{"Layer"}, rows)
-	response += "\n\n\n\n"
+	rows = [][]string{}
+	for _, d := range image.Inspect.RepoDigests {
+		repository, digest, _ := strings.Cut(d, "@")
+		rows = appendDetailRow(rows, repository, digest)
+	}
+	if len(rows) > 0 {
+		response += utils.CreateTable("# Digests", []string{"Repository", "Digest"}, rows)
+	}
+
+	return response
+}
+
+func getContentHealthcheck(healthcheck *container.HealthConfig) string {
+	if healthcheck == nil || len(healthcheck.Test) == 0 {
+		return ""
+	}
+
+	rows := [][]string{}
+	switch healthcheck.Test[0] {
+	case "NONE":
+		rows = appendDetailRow(rows, "Test", "disabled")
+	case "CMD-SHELL":
+		rows = appendDetailRow(rows, "Test", strings.Join(healthcheck.Test[1:], " "))
+	case "CMD":
+		rows = appendDetailRow(rows, "Test", formatCommand(healthcheck.Test[1:]))
+	default:
+		rows = appendDetailRow(rows, "Test", formatCommand(healthcheck.Test))
+	}
+
+	if healthcheck.Interval != 0 {
+		rows = appendDetailRow(rows, "Interval", healthcheck.Interval.String())
+	}
+	if healthcheck.Timeout != 0 {
+		rows = appendDetailRow(rows, "Timeout", healthcheck.Timeout.String())
+	}
+	if healthcheck.StartPeriod != 0 {
+		rows = appendDetailRow(rows, "Start period", healthcheck.StartPeriod.String())
+	}
+	if healthcheck.Retries != 0 {
+		rows = appendDetailRow(rows, "Retries", fmt.Sprintf("%d", healthcheck.Retries))
+	}
+
+	return utils.CreateTable("# Healthcheck", []string{"Type", "Value"}, rows)
+}
+
+// appendDetailRow adds a row for every line of the wrapped value, skipping empty values.
+func appendDetailRow(rows [][]string, name string, value string) [][]string {
+	if strings.TrimSpace(value) == "" {
+		return rows
+	}
+
+	for i, line := range utils.WrapText(value, detailValueWidth) {
+		if i > 0 {
+			name = ""
+		}
+		rows = append(rows, []string{escapeTableCell(name), escapeTableCell(line)})
+	}
+	return rows
+}
+
+// formatCommand returns the exec form of a command as written in a Dockerfile.
+func formatCommand(command []string) string {
+	if len(command) == 0 {
+		return ""
+	}
+
+	args := []string{}
+	for _, a := range command {
+		args = append(args, strconv.Quote(a))
+	}
+	return "[" + strings.Join(args, ", ") + "]"
+}
+
+func getContentImageRelations(image docker.MyImage, relations docker.MyImageRelations) string {
+	response := ""
+
+	rows := [][]string{}
+	for _, c := range relations.Containers {
+		rows = append(rows, []string{c.Name, c.State, c.Status})
+	}
+	if len(rows) == 0 {
+		rows = append(rows, []string{"No containers use this image", "", ""})
+	}
+	response += utils.CreateTable("# Containers using this image", []string{"Name", "State", "Status"}, rows)
+
+	rows = [][]string{}
+	for _, i := range relations.Parents {
+		rows = append(rows, []string{"Parent", i.GetName(), i.GetShortID(), fmt.Sprintf("%d", docker.CountSharedLayers(image, i))})
+	}
+	for _, i := range relations.Children {
+		rows = append(rows, []string{"Child", i.GetName(), i.GetShortID(), fmt.Sprintf("%d", docker.CountSharedLayers(image, i))})
+	}
+	for _, i := range relations.SharedLayers {
+		rows = append(rows, []string{"Shares layers", i.GetName(), i.GetShortID(), fmt.Sprintf("%d", docker.CountSharedLayers(image, i))})
+	}
 
+	if len(rows) > 0 {
+		response += "\n\n"
+		response += utils.CreateTable("# Related images (ctrl+d on image list for the full tree)", []string{"Relation", "Image", "ID", "Shared layers"}, rows)
+	}
+
+	response += "\n\n\n\n"
 	return response
 }
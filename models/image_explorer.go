@@ -0,0 +1,283 @@
+package models
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/ernesto27/dcli/docker"
+	"github.com/ernesto27/dcli/utils"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const explorerHeight = 25
+
+var explorerStyle = lipgloss.NewStyle().
+	BorderStyle(lipgloss.RoundedBorder()).
+	BorderForeground(lipgloss.Color("62")).
+	Width(140).
+	Padding(0, 1)
+
+var changeColors = map[docker.FileChange]string{
+	docker.FileAdded:    "\033[32m",
+	docker.FileModified: "\033[33m",
+	docker.FileDeleted:  "\033[31m",
+}
+
+type imageFilesMsg struct {
+	imageID string
+	files   docker.MyImageFiles
+	err     error
+}
+
+type fileNode struct {
+	name     string
+	file     docker.LayerFile
+	size     int64
+	depth    int
+	children []*fileNode
+	expanded bool
+}
+
+type ImageExplorer struct {
+	imageID    string
+	title      string
+	files      docker.MyImageFiles
+	loading    bool
+	err        error
+	layer      int
+	roots      []*fileNode
+	cursor     int
+	offset     int
+	showWasted bool
+}
+
+func NewImageExplorer(imageID string, title string) ImageExplorer {
+	return ImageExplorer{
+		imageID: imageID,
+		title:   title,
+		loading: true,
+	}
+}
+
+func (ie ImageExplorer) load(dockerClient *docker.Docker) tea.Cmd {
+	imageID := ie.imageID
+	return func() tea.Msg {
+		files, err := dockerClient.ImageFiles(imageID)
+		return imageFilesMsg{imageID: imageID, files: files, err: err}
+	}
+}
+
+func (ie ImageExplorer) Update(msg tea.Msg, m *model) (ImageExplorer, tea.Cmd) {
+	switch msg := msg.(type) {
+	case imageFilesMsg:
+		if msg.imageID != ie.imageID {
+			return ie, nil
+		}
+		ie.loading = false
+		ie.err = msg.err
+		ie.files = msg.files
+		ie.setLayer(0)
+		return ie, nil
+
+	case tea.KeyMsg:
+		if m.currentModel != MImageExplorer || ie.loading {
+			return ie, nil
+		}
+
+		visible := ie.visibleNodes()
+		switch msg.String() {
+		case "down":
+			if ie.cursor < len(visible)-1 {
+				ie.cursor++
+			}
+		case "up":
+			if ie.cursor > 0 {
+				ie.cursor--
+			}
+		case "right":
+			if ie.layer < len(ie.files.Layers)-1 {
+				ie.setLayer(ie.layer + 1)
+			}
+		case "left":
+			if ie.layer > 0 {
+				ie.setLayer(ie.layer - 1)
+			}
+		case "enter", " ":
+			if ie.cursor < len(visible) && len(visible[ie.cursor].children) > 0 {
+				visible[ie.cursor].expanded = !visible[ie.cursor].expanded
+			}
+		case "tab":
+			ie.showWasted = !ie.showWasted
+			ie.cursor = 0
+			ie.offset = 0
+		}
+
+		if ie.cursor < ie.offset {
+			ie.offset = ie.cursor
+		}
+		if ie.cursor >= ie.offset+explorerHeight {
+			ie.offset = ie.cursor - explorerHeight + 1
+		}
+	}
+
+	return ie, nil
+}
+
+func (ie *ImageExplorer) setLayer(layer int) {
+	ie.layer = layer
+	ie.cursor = 0
+	ie.offset = 0
+	ie.roots = nil
+	if layer < len(ie.files.Layers) {
+		ie.roots = buildFileTree(ie.files.Layers[layer].Files)
+	}
+}
+
+func (ie ImageExplorer) visibleNodes() []*fileNode {
+	if ie.showWasted {
+		return nil
+	}
+
+	nodes := []*fileNode{}
+	var walk func(list []*fileNode)
+	walk = func(list []*fileNode) {
+		for _, n := range list {
+			nodes = append(nodes, n)
+			if n.expanded {
+				walk(n.children)
+			}
+		}
+	}
+	walk(ie.roots)
+	return nodes
+}
+
+func (ie ImageExplorer) View() string {
+	title := titleTableStyle("FILES " + ie.title)
+	help := helpStyle("\n  ↑/↓: Navigate • ←/→: Layer • enter: Expand/collapse • tab: Files/wasted space • Esc: back\n")
+
+	if ie.loading {
+		return title + "\n\nExporting image, this can take a while for big images..." + help
+	}
+	if ie.err != nil {
+		return title + "\n\nError: " + ie.err.Error() + help
+	}
+
+	if ie.showWasted {
+		return title + "\n" + explorerStyle.Render(ie.wastedView()) + help
+	}
+
+	if len(ie.files.Layers) == 0 {
+		return title + "\n\nThe image has no layers" + help
+	}
+
+	header := fmt.Sprintf("Layer %d/%d %s • \033[32mA\033[0m added • \033[33mM\033[0m modified • \033[31mD\033[0m deleted",
+		ie.layer+1, len(ie.files.Layers), ie.files.Layers[ie.layer].Layer)
+
+	lines := []string{}
+	visible := ie.visibleNodes()
+	for i := ie.offset; i < len(visible) && i < ie.offset+explorerHeight; i++ {
+		line := renderFileNode(visible[i])
+		if i == ie.cursor {
+			line = lipgloss.NewStyle().Foreground(lipgloss.Color("229")).Background(lipgloss.Color("57")).Render(line)
+		}
+		lines = append(lines, line)
+	}
+	if len(visible) == 0 {
+		lines = append(lines, "(no filesystem changes in this layer)")
+	}
+
+	return title + "\n" + header + "\n" + explorerStyle.Render(strings.Join(lines, "\n")) + help
+}
+
+func (ie ImageExplorer) wastedView() string {
+	s := strings.Builder{}
+	s.WriteString(fmt.Sprintf("Wasted space: %s in %d files overwritten or deleted in later layers\n\n",
+		utils.FormatSize(ie.files.WastedSize), len(ie.files.Wasted)))
+
+	for i, w := range ie.files.Wasted {
+		if i >= explorerHeight {
+			s.WriteString(fmt.Sprintf("... and %d more", len(ie.files.Wasted)-i))
+			break
+		}
+		s.WriteString(fmt.Sprintf("%12s  %3dx  %s\n", utils.FormatSize(w.Size), w.Count, w.Path))
+	}
+
+	return s.String()
+}
+
+func renderFileNode(n *fileNode) string {
+	icon := "  "
+	if len(n.children) > 0 {
+		icon = "▸ "
+		if n.expanded {
+			icon = "▾ "
+		}
+	}
+
+	change := " "
+	if n.file.Change != "" {
+		change = changeColors[n.file.Change] + string(n.file.Change) + "\033[0m"
+	}
+
+	name := n.name
+	if n.file.IsDir {
+		name += "/"
+	}
+
+	return fmt.Sprintf("%s %12s  %s %s%s%s", change, utils.FormatSize(n.size), n.file.Mode, strings.Repeat("  ", n.depth), icon, name)
+}
+
+func buildFileTree(files []docker.LayerFile) []*fileNode {
+	nodes := map[string]*fileNode{}
+	roots := []*fileNode{}
+
+	var getNode func(p string) *fileNode
+	getNode = func(p string) *fileNode {
+		if n, ok := nodes[p]; ok {
+			return n
+		}
+
+		n := &fileNode{name: path.Base(p), file: docker.LayerFile{Path: p, IsDir: true}}
+		nodes[p] = n
+
+		parent := path.Dir(p)
+		if parent == "." {
+			roots = append(roots, n)
+		} else {
+			pn := getNode(parent)
+			n.depth = pn.depth + 1
+			pn.children = append(pn.children, n)
+		}
+		return n
+	}
+
+	for _, f := range files {
+		n := getNode(f.Path)
+		n.file = f
+	}
+
+	var finish func(list []*fileNode) int64
+	finish = func(list []*fileNode) int64 {
+		sort.Slice(list, func(i, j int) bool {
+			return list[i].name < list[j].name
+		})
+
+		var total int64
+		for _, n := range list {
+			n.size = n.file.Size
+			if len(n.children) > 0 {
+				n.size = finish(n.children)
+			}
+			total += n.size
+		}
+		return total
+	}
+	finish(roots)
+
+	return roots
+}
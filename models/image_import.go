@@ -0,0 +1,119 @@
+package models
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/ernesto27/dcli/docker"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type ImageImport struct {
+	Form
+}
+
+func NewImageImport() ImageImport {
+	return ImageImport{
+		NewForm([]FormField{
+			{Label: "Filesystem tar archive (.tar or .tar.gz)", Placeholder: "container.tar"},
+			{Label: "Repository:tag", Placeholder: "myapp:snapshot"},
+			{Label: "Message (optional)", Placeholder: "Snapshot of the staging database"},
+			{Label: "Changes (Dockerfile instructions separated by ;, optional)", Placeholder: `CMD ["/bin/sh"]; ENV DEBUG=1`},
+		}),
+	}
+}
+
+func (ii ImageImport) View() string {
+	return ii.Form.View("Import image from filesystem tar archive")
+}
+
+func (ii ImageImport) Update(msg tea.Msg, m *model) (ImageImport, tea.Cmd) {
+	if m.currentModel != MImageImport {
+		return ii, nil
+	}
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "enter":
+			path := ii.Value(0)
+			if _, err := os.Stat(path); err != nil {
+				ii.MessageError = err.Error()
+				return ii, nil
+			}
+
+			reference := ii.Value(1)
+			if reference == "" {
+				ii.MessageError = "repository:tag is required"
+				return ii, nil
+			}
+
+			changes, err := parseCommitChanges(ii.Value(3))
+			if err != nil {
+				ii.MessageError = err.Error()
+				return ii, nil
+			}
+
+			updates := importImage(m.dockerClient, path, reference, ii.Value(2), changes)
+			return ii, m.startProgress("Import "+path+" as "+reference, MImageList, updates, func(m *model) tea.Cmd {
+				images, err := m.dockerClient.ImageList()
+				if err != nil {
+					fmt.Println(err)
+				}
+
+				m.imageList = NewImageList(images, "")
+				tag := withDefaultTag(reference)
+				for _, img := range images {
+					if slices.Contains(img.GetTags(), tag) {
+						m.imageList.selectImage(img.GetID())
+					}
+				}
+				return nil
+			})
+		}
+	}
+
+	var cmd tea.Cmd
+	ii.Form, cmd = ii.Form.Update(msg)
+	return ii, cmd
+}
+
+// withDefaultTag adds the latest tag to a reference without one, like docker does.
+func withDefaultTag(reference string) string {
+	if strings.Contains(reference[strings.LastIndex(reference, "/")+1:], ":") {
+		return reference
+	}
+	return reference + ":latest"
+}
+
+func importImage(dockerClient *docker.Docker, path string, reference string, message string, changes []string) <-chan progressUpdate {
+	updates := make(chan progressUpdate)
+
+	go func() {
+		defer close(updates)
+
+		f, err := os.Open(path)
+		if err != nil {
+			updates <- progressUpdate{err: err}
+			return
+		}
+		defer f.Close()
+
+		pw := &progressWriter{updates: updates, label: "Uploaded", total: docker.HostPathSize(path)}
+		body, err := dockerClient.ImageImport(io.TeeReader(f, pw), reference, message, changes)
+		if err != nil {
+			updates <- progressUpdate{err: err}
+			return
+		}
+
+		for u := range jsonMessages(body) {
+			updates <- u
+		}
+	}()
+
+	return updates
+}
@@ -0,0 +1,23 @@
+package models
+
+import "testing"
+
+func TestWithDefaultTag(t *testing.T) {
+	tests := []struct {
+		reference string
+		want      string
+	}{
+		{reference: "myapp", want: "myapp:latest"},
+		{reference: "myapp:snapshot", want: "myapp:snapshot"},
+		{reference: "localhost:5000/myapp", want: "localhost:5000/myapp:latest"},
+		{reference: "localhost:5000/myapp:1.0", want: "localhost:5000/myapp:1.0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.reference, func(t *testing.T) {
+			if got := withDefaultTag(tt.reference); got != tt.want {
+				t.Errorf("withDefaultTag() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
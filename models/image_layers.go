@@ -0,0 +1,125 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ernesto27/dcli/docker"
+	"github.com/ernesto27/dcli/utils"
+
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+var layerDetailStyle = lipgloss.NewStyle().
+	BorderStyle(lipgloss.RoundedBorder()).
+	BorderForeground(lipgloss.Color("62")).
+	Width(140).
+	Padding(0, 1)
+
+type ImageLayers struct {
+	table  table.Model
+	layers []docker.MyImageLayer
+	title  string
+}
+
+func NewImageLayers(image docker.MyImage, title string) ImageLayers {
+	layers := image.GetLayers()
+
+	return ImageLayers{
+		table:  newImageLayersTable(layers),
+		layers: layers,
+		title:  "LAYERS " + title,
+	}
+}
+
+func newImageLayersTable(layers []docker.MyImageLayer) table.Model {
+	columns := []table.Column{
+		{Title: "#", Width: 4},
+		// the largest layers are marked in red, like ▲ 1023.99 MB
+		{Title: "Size", Width: 12 + ansiWidth},
+		{Title: "Cumulative", Width: 14},
+		{Title: "Created", Width: 14},
+		{Title: "Command", Width: 90},
+	}
+
+	t := table.New(
+		table.WithColumns(columns),
+		table.WithRows(GetImageLayerRows(layers)),
+		table.WithFocused(true),
+		table.WithWidth(180),
+		table.WithHeight(15),
+	)
+
+	s := table.DefaultStyles()
+	s.Header = s.Header.
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderForeground(lipgloss.Color("240")).
+		BorderBottom(true).
+		Bold(false)
+	s.Selected = s.Selected.
+		Foreground(lipgloss.Color("229")).
+		Background(lipgloss.Color("57")).
+		Bold(false)
+	t.SetStyles(s)
+
+	return t
+}
+
+func (il ImageLayers) View() string {
+	total := int64(0)
+	if len(il.layers) > 0 {
+		total = il.layers[len(il.layers)-1].CumulativeSize
+	}
+
+	header := fmt.Sprintf("%d layers • total %s • \033[31m%s\033[0m largest layers", len(il.layers), utils.FormatSize(total), "▲")
+
+	detail := ""
+	if il.table.Cursor() >= 0 && il.table.Cursor() < len(il.layers) {
+		l := il.layers[il.table.Cursor()]
+		diffID := l.DiffID
+		if diffID == "" {
+			diffID = "(no filesystem changes)"
+		}
+
+		detail = fmt.Sprintf("Layer: %s\nDiff ID: %s\nCreated: %s • Size: %s • Cumulative: %s\n\n%s",
+			l.ID, diffID, docker.FormatTimestamp(l.Created), utils.FormatSize(l.Size), utils.FormatSize(l.CumulativeSize), l.CreatedBy)
+		if l.Comment != "" {
+			detail += "\n\n" + l.Comment
+		}
+	}
+
+	return titleTableStyle(il.title) + "\n" + header + "\n" + baseStyle.Render(il.table.View()) + "\n" +
+		layerDetailStyle.Render(detail) + helpStyle("\n  ↑/↓: Navigate • Esc: back\n")
+}
+
+func (il ImageLayers) Update(msg tea.Msg, m *model) (ImageLayers, tea.Cmd) {
+	if m.currentModel != MImageLayers {
+		return il, nil
+	}
+
+	il.table, _ = il.table.Update(msg)
+	return il, nil
+}
+
+func GetImageLayerRows(layers []docker.MyImageLayer) []table.Row {
+	rows := []table.Row{}
+	for index, l := range layers {
+		size := utils.FormatSize(l.Size)
+		if l.Largest {
+			size = "\033[31m▲ " + size + "\033[0m"
+		}
+
+		command := strings.Join(strings.Fields(l.CreatedBy), " ")
+		rows = append(rows, table.Row{
+			fmt.Sprintf("%d", index+1),
+			size,
+			utils.FormatSize(l.CumulativeSize),
+			docker.FormatTimestamp(l.Created),
+			command,
+		})
+	}
+
+	return rows
+}
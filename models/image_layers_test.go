@@ -0,0 +1,20 @@
+package models
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ernesto27/dcli/docker"
+)
+
+func TestImageLayersTableLargest(t *testing.T) {
+	layers := []docker.MyImageLayer{
+		{Size: 1023*1024*1024 + 512*1024, CumulativeSize: 2 * 1024 * 1024 * 1024, Largest: true},
+		{Size: 512, CumulativeSize: 2 * 1024 * 1024 * 1024},
+	}
+
+	view := newImageLayersTable(layers).View()
+	if !strings.Contains(view, "\033[31m▲ 1023.50 MB\033[0m") {
+		t.Errorf("newImageLayersTable().View() = %q, want the largest layer size with its reset code", view)
+	}
+}
@@ -22,8 +22,8 @@ type ImageList struct {
 
 func NewImageList(images []docker.MyImage, query string) ImageList {
 	columns := []table.Column{
-		{Title: "ID", Width: 20},
-		{Title: "Image", Width: 40},
+		{Title: "ID", Width: 13},
+		{Title: "Image", Width: 50},
 		{Title: "Size", Width: 20},
 		{Title: "Created", Width: 20},
 	}
@@ -56,6 +56,16 @@ func NewImageList(images []docker.MyImage, query string) ImageList {
 	}
 }
 
+// selectImage moves the cursor to the image with the given ID.
+func (il *ImageList) selectImage(imageID string) {
+	for i, row := range il.table.Rows() {
+		if row[0] == imageID {
+			il.table.SetCursor(i)
+			return
+		}
+	}
+}
+
 func (il ImageList) View(commands string, m *model) string {
 	return m.renderTable(il.title, il.table.View(), commands)
 }
@@ -76,7 +86,7 @@ func (il ImageList) Update(msg tea.Msg, m *model) (table.Model, tea.Cmd) {
 					fmt.Println(err)
 				}
 
-				imgView, err := NewImageDetail(img, utils.CreateTable)
+				imgView, err := NewImageDetail(img, m.dockerClient.GetImageRelations(img), utils.CreateTable)
 				if err != nil {
 					fmt.Println(err)
 				}
@@ -88,9 +98,69 @@ func (il ImageList) Update(msg tea.Msg, m *model) (table.Model, tea.Cmd) {
 			m.imageSearch.textInput.SetValue("")
 			m.currentModel = MImageSearch
 		case "ctrl+o":
-			ov := NewImageOptions(m.imageList.table.SelectedRow()[1])
+			if len(m.imageList.table.SelectedRow()) == 0 {
+				return il.table, nil
+			}
+
+			img, err := m.dockerClient.GetImageByID(m.imageList.table.SelectedRow()[0])
+			if err != nil {
+				fmt.Println(err)
+			}
+
+			ov := NewImageOptions(m.imageList.table.SelectedRow()[1], m.imageList.table.SelectedRow()[0], img.GetTags(), m.dockerClient.GetImageRelations(img).Containers)
 			m.imageOptions = ov
 			m.currentModel = MImageOptions
+		case "ctrl+s":
+			image := ""
+			if len(m.imageList.table.SelectedRow()) != 0 {
+				image = m.imageList.table.SelectedRow()[1]
+				if image == "<none>" {
+					image = m.imageList.table.SelectedRow()[0]
+				}
+			}
+			m.imageSave = NewImageSave(image)
+			m.currentModel = MImageSave
+		case "ctrl+t":
+			if len(m.imageList.table.SelectedRow()) != 0 {
+				img, err := m.dockerClient.GetImageByID(m.imageList.table.SelectedRow()[0])
+				if err != nil {
+					fmt.Println(err)
+				}
+
+				m.imageLayers = NewImageLayers(img, m.imageList.table.SelectedRow()[1])
+				m.currentModel = MImageLayers
+			}
+		case "ctrl+e":
+			if len(m.imageList.table.SelectedRow()) != 0 {
+				m.imageExplorer = NewImageExplorer(m.imageList.table.SelectedRow()[0], m.imageList.table.SelectedRow()[1])
+				m.currentModel = MImageExplorer
+				return il.table, m.imageExplorer.load(m.dockerClient)
+			}
+		case "ctrl+d":
+			selectedID := ""
+			if len(m.imageList.table.SelectedRow()) != 0 {
+				selectedID = m.imageList.table.SelectedRow()[0]
+			}
+			m.imageTree = NewImageTree(m.dockerClient.Images, m.dockerClient.Containers, selectedID)
+			m.currentModel = MImageTree
+		case "ctrl+l":
+			m.imageLoad = NewImageLoad()
+			m.currentModel = MImageLoad
+		case "ctrl+u":
+			m.imageImport = NewImageImport()
+			m.currentModel = MImageImport
+		case "ctrl+y":
+			if len(m.imageList.table.SelectedRow()) == 0 {
+				return il.table, nil
+			}
+
+			img, err := m.dockerClient.GetImageByID(m.imageList.table.SelectedRow()[0])
+			if err != nil {
+				fmt.Println(err)
+			}
+
+			m.valueOptions = NewValueOptions(m.imageList.table.SelectedRow()[1], imageValues(img), false, MImageList)
+			m.currentModel = MValueOptions
 		case "ctrl+a":
 			orderDescImage = !orderDescImage
 			images := m.dockerClient.GetImagesOrderBySize(orderDescImage)
@@ -108,15 +178,18 @@ func GetImageRows(images []docker.MyImage, query string) []table.Row {
 		filtered = images
 	} else {
 		for _, i := range images {
-			if strings.Contains(strings.ToLower(i.Summary.RepoTags[0]), strings.ToLower(query)) {
-				filtered = append(filtered, i)
+			for _, name := range append(i.GetTags(), i.GetName()) {
+				if strings.Contains(strings.ToLower(name), strings.ToLower(query)) {
+					filtered = append(filtered, i)
+					break
+				}
 			}
 		}
 	}
 
 	rowsItems := []table.Row{}
 	for _, i := range filtered {
-		item := []string{i.Summary.ID, i.Summary.RepoTags[0], i.GetFormatSize(), i.GetFormatTimestamp()}
+		item := []string{i.GetID(), i.GetName(), i.GetFormatSize(), i.GetFormatTimestamp()}
 		rowsItems = append(rowsItems, item)
 	}
 
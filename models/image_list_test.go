@@ -62,6 +62,27 @@ func TestGetImagesRows(t *testing.T) {
 			},
 			want: []table.Row{{"1234567890", "nginx:latest", "", ""}},
 		},
+		{
+			name: "should search by any tag and keep full ids",
+			args: args{
+				images: []docker.MyImage{
+					{
+						Summary: types.ImageSummary{
+							ID:       "sha256:6a59f1cbb8d28ac484176d52c473494859a512ddba3ea62a547258cf16c9b3ae",
+							RepoTags: []string{"app:1.0", "registry.example.com/team/app:1.0"},
+						},
+					},
+					{
+						Summary: types.ImageSummary{
+							ID:       "sha256:1234",
+							RepoTags: []string{"<none>:<none>"},
+						},
+					},
+				},
+				query: "registry",
+			},
+			want: []table.Row{{"6a59f1cbb8d28ac484176d52c473494859a512ddba3ea62a547258cf16c9b3ae", "app:1.0", "", ""}},
+		},
 	}
 
 	for _, tt := range tests {
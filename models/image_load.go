@@ -0,0 +1,87 @@
+package models
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/ernesto27/dcli/docker"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type ImageLoad struct {
+	Form
+}
+
+func NewImageLoad() ImageLoad {
+	return ImageLoad{
+		NewForm([]FormField{
+			{Label: "Tar archive (.tar or .tar.gz)", Placeholder: "images.tar"},
+		}),
+	}
+}
+
+func (il ImageLoad) View() string {
+	return il.Form.View("Load images from tar archive")
+}
+
+func (il ImageLoad) Update(msg tea.Msg, m *model) (ImageLoad, tea.Cmd) {
+	if m.currentModel != MImageLoad {
+		return il, nil
+	}
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "enter":
+			path := il.Value(0)
+			if _, err := os.Stat(path); err != nil {
+				il.MessageError = err.Error()
+				return il, nil
+			}
+
+			updates := loadImages(m.dockerClient, path)
+			return il, m.startProgress("Load "+path, MImageList, updates, func(m *model) tea.Cmd {
+				images, err := m.dockerClient.ImageList()
+				if err != nil {
+					fmt.Println(err)
+				}
+				m.imageList = NewImageList(images, "")
+				return nil
+			})
+		}
+	}
+
+	var cmd tea.Cmd
+	il.Form, cmd = il.Form.Update(msg)
+	return il, cmd
+}
+
+func loadImages(dockerClient *docker.Docker, path string) <-chan progressUpdate {
+	updates := make(chan progressUpdate)
+
+	go func() {
+		defer close(updates)
+
+		f, err := os.Open(path)
+		if err != nil {
+			updates <- progressUpdate{err: err}
+			return
+		}
+		defer f.Close()
+
+		updates <- progressUpdate{text: "Uploading " + path + "..."}
+
+		body, err := dockerClient.ImageLoad(f)
+		if err != nil {
+			updates <- progressUpdate{err: err}
+			return
+		}
+
+		for u := range jsonMessages(body) {
+			updates <- u
+		}
+	}()
+
+	return updates
+}
@@ -2,25 +2,66 @@ package models
 
 import (
 	"fmt"
+	"strings"
+
+	"github.com/ernesto27/dcli/docker"
 
 	tea "github.com/charmbracelet/bubbletea"
 )
 
 type ImageOptions struct {
 	Options
+	imageID string
+	tags    []string
 }
 
-func NewImageOptions(image string) ImageOptions {
-	choices := []string{Remove, ForceRemove}
-
+func NewImageOptions(image string, imageID string, tags []string, containers []docker.MyContainer) ImageOptions {
 	return ImageOptions{
-		Options{
-			Cursor:  0,
-			Choice:  "",
-			Choices: choices,
-			Text1:   image,
+		Options: Options{
+			Cursor:   0,
+			Choice:   "",
+			Choices:  []string{Remove, ForceRemove, Tag, Push, Untag},
+			Text1:    image,
+			Disabled: imageDisabledChoices(tags, containers),
 		},
+		imageID: imageID,
+		tags:    tags,
+	}
+}
+
+func imageDisabledChoices(tags []string, containers []docker.MyContainer) map[string]string {
+	disabled := map[string]string{}
+
+	switch len(tags) {
+	case 0:
+		disabled[Push] = "the image has no tags, tag it first"
+		disabled[Untag] = "the image has no tags"
+	case 1:
+		disabled[Untag] = "the image has only one tag, use Remove"
+	default:
+		disabled[Remove] = "the image has several tags, use Force Remove or Untag"
 	}
+
+	running := []string{}
+	stopped := []string{}
+	for _, c := range containers {
+		if c.State == "running" || c.State == "paused" || c.State == "restarting" {
+			running = append(running, c.Name)
+		} else {
+			stopped = append(stopped, c.Name)
+		}
+	}
+
+	switch {
+	case len(running) > 0:
+		reason := "used by running containers: " + strings.Join(running, ", ")
+		disabled[Remove] = reason
+		disabled[ForceRemove] = reason
+	case len(stopped) > 0:
+		disabled[Remove] = "used by containers: " + strings.Join(stopped, ", ") + ", use Force Remove"
+	}
+
+	return disabled
 }
 
 func (o ImageOptions) View() string {
@@ -37,18 +78,57 @@ func (o ImageOptions) Update(msg tea.Msg, m *model) (ImageOptions, tea.Cmd) {
 	case tea.KeyMsg:
 		switch msg.String() {
 		case "enter":
+			if reason := o.disabledReason(); reason != "" {
+				o.MessageError = reason
+				return o, nil
+			}
+
 			errAction := false
 			option := m.imageOptions.Choices[m.imageOptions.Cursor]
 
+			switch option {
+			case Tag:
+				m.imageTag = NewImageTag(o.imageID, o.Text1)
+				m.currentModel = MImageTag
+				return o, nil
+			case Push:
+				if len(o.tags) == 1 {
+					cmd, err := m.pushImage(o.tags[0])
+					if err != nil {
+						o.MessageError = err.Error()
+						return o, nil
+					}
+					return o, cmd
+				}
+				m.imageTagOptions = NewImageTagOptions(Push, o.Text1, o.imageID, o.tags, MImageOptions)
+				m.currentModel = MImageTagOptions
+				return o, nil
+			case Untag:
+				m.imageTagOptions = NewImageTagOptions(Untag, o.Text1, o.imageID, o.tags, MImageOptions)
+				m.currentModel = MImageTagOptions
+				return o, nil
+			}
+
 			force := false
 			if option == ForceRemove {
 				force = true
 			}
 
-			err := m.dockerClient.ImageRemove(m.imageList.table.SelectedRow()[1], force)
+			err := m.dockerClient.ImageRemove(o.imageID, force)
 			if err != nil {
 				o.MessageError = err.Error()
 				errAction = true
+
+				img, errImage := m.dockerClient.GetImageByID(o.imageID)
+				if errImage == nil {
+					names := []string{}
+					for _, c := range m.dockerClient.GetImageRelations(img).Containers {
+						names = append(names, c.Name+" ("+c.State+")")
+					}
+					if len(names) > 0 {
+						o.MessageError += "\n\nImage used by containers: " + strings.Join(names, ", ")
+					}
+				}
 			}
 
 			if !errAction {
@@ -77,3 +157,12 @@ func (o ImageOptions) Update(msg tea.Msg, m *model) (ImageOptions, tea.Cmd) {
 
 	return o, nil
 }
+
+func (m *model) pushImage(tag string) (tea.Cmd, error) {
+	body, err := m.dockerClient.ImagePush(tag)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.startProgress("Push "+tag, MImageList, jsonMessages(body), nil), nil
+}
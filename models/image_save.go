@@ -0,0 +1,136 @@
+package models
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ernesto27/dcli/docker"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type ImageSave struct {
+	Form
+}
+
+func NewImageSave(image string) ImageSave {
+	file := strings.NewReplacer("/", "_", ":", "_").Replace(image) + ".tar"
+
+	return ImageSave{
+		NewForm([]FormField{
+			{Label: "Images (separated by comma)", Placeholder: "nginx:latest, redis:7", Value: image},
+			{Label: "Output file (.tar, .tar.gz to compress)", Placeholder: "images.tar", Value: file},
+		}),
+	}
+}
+
+func (is ImageSave) View() string {
+	return is.Form.View("Save images to tar archive")
+}
+
+func (is ImageSave) Update(msg tea.Msg, m *model) (ImageSave, tea.Cmd) {
+	if m.currentModel != MImageSave {
+		return is, nil
+	}
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "enter":
+			images := []string{}
+			for _, i := range strings.Split(is.Value(0), ",") {
+				if strings.TrimSpace(i) != "" {
+					images = append(images, strings.TrimSpace(i))
+				}
+			}
+
+			path := is.Value(1)
+			if len(images) == 0 || path == "" {
+				is.MessageError = "images and output file are required"
+				return is, nil
+			}
+
+			title := fmt.Sprintf("Save %s to %s", strings.Join(images, ", "), path)
+			updates := saveImages(m.dockerClient, images, path)
+			return is, m.startProgress(title, MImageList, updates, nil)
+		}
+	}
+
+	var cmd tea.Cmd
+	is.Form, cmd = is.Form.Update(msg)
+	return is, cmd
+}
+
+func saveImages(dockerClient *docker.Docker, images []string, path string) <-chan progressUpdate {
+	updates := make(chan progressUpdate)
+
+	go func() {
+		defer close(updates)
+
+		body, err := dockerClient.ImageSave(images)
+		if err != nil {
+			updates <- progressUpdate{err: err}
+			return
+		}
+		defer body.Close()
+
+		pw := &progressWriter{
+			updates: updates,
+			label:   "Saved",
+			total:   dockerClient.GetImagesSize(images),
+		}
+
+		if err := writeArchive(path, io.TeeReader(body, pw)); err != nil {
+			updates <- progressUpdate{err: err}
+			return
+		}
+
+		pw.report()
+		updates <- progressUpdate{text: "Images saved to " + path}
+	}()
+
+	return updates
+}
+
+// writeArchive writes the archive to a temporary file next to path and renames it when it is complete,
+// a failed write does not leave a partial archive. Paths ending with .gz or .tgz are compressed.
+func writeArchive(path string, r io.Reader) (err error) {
+	f, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			f.Close()
+			os.Remove(f.Name())
+		}
+	}()
+
+	var out io.Writer = f
+	var gz *gzip.Writer
+	if strings.HasSuffix(path, ".gz") || strings.HasSuffix(path, ".tgz") {
+		gz = gzip.NewWriter(f)
+		out = gz
+	}
+
+	if _, err = io.Copy(out, r); err != nil {
+		return err
+	}
+	if gz != nil {
+		if err = gz.Close(); err != nil {
+			return err
+		}
+	}
+	// the temporary file is only readable by the user, like os.Create the archive is readable by everyone
+	if err = f.Chmod(0o644); err != nil {
+		return err
+	}
+	if err = f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
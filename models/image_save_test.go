@@ -0,0 +1,53 @@
+package models
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+type failingReader struct{}
+
+func (failingReader) Read(p []byte) (int, error) {
+	return 0, errors.New("connection reset")
+}
+
+func TestWriteArchive(t *testing.T) {
+	tests := []struct {
+		name    string
+		r       io.Reader
+		wantErr bool
+	}{
+		{name: "should rename the archive when it is complete", r: strings.NewReader("layers")},
+		{name: "should not leave a partial archive when the save fails", r: io.MultiReader(strings.NewReader("layers"), failingReader{}), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			path := filepath.Join(dir, "images.tar")
+
+			err := writeArchive(path, tt.r)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("writeArchive() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			entries, _ := os.ReadDir(dir)
+			want := 1
+			if tt.wantErr {
+				want = 0
+			}
+			if len(entries) != want {
+				t.Errorf("writeArchive() left %d files, want %d", len(entries), want)
+			}
+			if !tt.wantErr {
+				if data, _ := os.ReadFile(path); string(data) != "layers" {
+					t.Errorf("writeArchive() wrote %q, want %q", data, "layers")
+				}
+			}
+		})
+	}
+}
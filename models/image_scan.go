@@ -0,0 +1,284 @@
+package models
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ernesto27/dcli/docker"
+	"github.com/ernesto27/dcli/scan"
+	"github.com/ernesto27/dcli/utils"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/glamour"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type ImageScan struct {
+	Form
+	imageID string
+	image   string
+}
+
+func NewImageScan(imageID string, image string) ImageScan {
+	file := strings.NewReplacer("/", "_", ":", "_").Replace(image) + ".spdx.json"
+
+	return ImageScan{
+		Form: NewForm([]FormField{
+			{Label: "SBOM format (spdx or cyclonedx)", Placeholder: scan.FormatSPDX, Value: scan.FormatSPDX},
+			{Label: "SBOM output file (empty to skip)", Placeholder: "sbom.json", Value: file},
+			{Label: "Vulnerability database (json file, optional)", Placeholder: "vulnerabilities.json"},
+		}),
+		imageID: imageID,
+		image:   image,
+	}
+}
+
+func (is ImageScan) View() string {
+	return is.Form.View("Scan image " + is.image)
+}
+
+func (is ImageScan) Update(msg tea.Msg, m *model) (ImageScan, tea.Cmd) {
+	if m.currentModel != MImageScan {
+		return is, nil
+	}
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "enter":
+			format := strings.ToLower(is.Value(0))
+			if format != scan.FormatSPDX && format != scan.FormatCycloneDX {
+				is.MessageError = fmt.Sprintf("format must be %s or %s", scan.FormatSPDX, scan.FormatCycloneDX)
+				return is, nil
+			}
+
+			dbPath := is.Value(2)
+			if dbPath != "" {
+				if _, err := os.Stat(dbPath); err != nil {
+					is.MessageError = err.Error()
+					return is, nil
+				}
+			}
+
+			m.imageScanResult = NewImageScanResult(is.imageID, is.image)
+			m.currentModel = MImageScanResult
+			return is, scanImage(m.dockerClient, is.imageID, is.image, format, is.Value(1), dbPath)
+		}
+	}
+
+	var cmd tea.Cmd
+	is.Form, cmd = is.Form.Update(msg)
+	return is, cmd
+}
+
+type imageScanMsg struct {
+	imageID  string
+	result   scan.Result
+	findings []scan.Finding
+	sbomPath string
+	dbPath   string
+	err      error
+}
+
+func scanImage(dockerClient *docker.Docker, imageID string, image string, format string, sbomPath string, dbPath string) tea.Cmd {
+	return func() tea.Msg {
+		msg := imageScanMsg{imageID: imageID, sbomPath: sbomPath, dbPath: dbPath}
+
+		body, err := dockerClient.ImageSave([]string{imageID})
+		if err != nil {
+			msg.err = err
+			return msg
+		}
+		defer body.Close()
+
+		msg.result, msg.err = scan.ScanImage(image, body)
+		if msg.err != nil {
+			return msg
+		}
+
+		if sbomPath != "" {
+			f, err := os.Create(sbomPath)
+			if err != nil {
+				msg.err = err
+				return msg
+			}
+			defer f.Close()
+
+			if msg.err = scan.WriteSBOM(f, format, msg.result, time.Now()); msg.err != nil {
+				return msg
+			}
+		}
+
+		if dbPath != "" {
+			db, err := scan.LoadDatabase(dbPath)
+			if err != nil {
+				msg.err = err
+				return msg
+			}
+			msg.findings = scan.Match(msg.result.Packages, db)
+		}
+
+		return msg
+	}
+}
+
+type ImageScanResult struct {
+	viewport viewport.Model
+	imageID  string
+	image    string
+	loading  bool
+	err      error
+}
+
+func NewImageScanResult(imageID string, image string) ImageScanResult {
+	vp := viewport.New(120, 30)
+	vp.Style = lipgloss.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("62")).
+		PaddingRight(2)
+
+	return ImageScanResult{
+		viewport: vp,
+		imageID:  imageID,
+		image:    image,
+		loading:  true,
+	}
+}
+
+func (sr ImageScanResult) View() string {
+	title := titleTableStyle("SCAN " + sr.image)
+	help := helpStyle("\n  ↑/↓: Navigate • Esc: back to image detail\n")
+
+	if sr.loading {
+		return title + "\n\nExporting and scanning image, this can take a while for big images..." + help
+	}
+	if sr.err != nil {
+		return title + "\n\nError: " + sr.err.Error() + help
+	}
+
+	return title + "\n" + sr.viewport.View() + help
+}
+
+func (sr ImageScanResult) Update(msg tea.Msg, m *model) (ImageScanResult, tea.Cmd) {
+	switch msg := msg.(type) {
+	case imageScanMsg:
+		if msg.imageID != sr.imageID {
+			return sr, nil
+		}
+
+		sr.loading = false
+		sr.err = msg.err
+		if sr.err != nil {
+			return sr, nil
+		}
+
+		renderer, err := glamour.NewTermRenderer(
+			glamour.WithAutoStyle(),
+			glamour.WithWordWrap(120),
+		)
+		if err != nil {
+			sr.err = err
+			return sr, nil
+		}
+
+		str, err := renderer.Render(getContentImageScan(msg))
+		if err != nil {
+			sr.err = err
+			return sr, nil
+		}
+		sr.viewport.SetContent(str)
+		return sr, nil
+	}
+
+	if m.currentModel != MImageScanResult {
+		return sr, nil
+	}
+
+	sr.viewport, _ = sr.viewport.Update(msg)
+	return sr, nil
+}
+
+func getContentImageScan(msg imageScanMsg) string {
+	result := msg.result
+
+	osName := result.OS.PrettyName
+	if osName == "" {
+		osName = strings.TrimSpace(result.OS.ID + " " + result.OS.VersionID)
+	}
+	if osName == "" {
+		osName = "unknown"
+	}
+
+	rows := [][]string{
+		{"Image", result.Image},
+		{"OS", osName},
+		{"Packages", strconv.Itoa(len(result.Packages))},
+	}
+
+	count := result.CountByType()
+	types := []string{}
+	for t := range count {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+	for _, t := range types {
+		rows = append(rows, []string{"  " + t, strconv.Itoa(count[t])})
+	}
+
+	if msg.sbomPath != "" {
+		rows = append(rows, []string{"SBOM", msg.sbomPath})
+	}
+	response := utils.CreateTable("# Scan", []string{"Type", "Value"}, rows)
+
+	if len(result.Warnings) > 0 {
+		response += "\n\n## Warnings\n\n"
+		for _, w := range result.Warnings {
+			response += "- " + w + "\n"
+		}
+	}
+
+	response += "\n\n---\n\n"
+	if msg.dbPath == "" {
+		response += "## Vulnerabilities\n\nNo vulnerability database selected, only the SBOM was generated.\n"
+	} else {
+		rows = [][]string{}
+		for _, c := range scan.CountBySeverity(msg.findings) {
+			rows = append(rows, []string{c[0], c[1]})
+		}
+		response += utils.CreateTable("# Vulnerabilities", []string{"Severity", "Count"}, rows)
+
+		rows = [][]string{}
+		for _, f := range msg.findings {
+			fixed := f.Fixed
+			if fixed == "" {
+				fixed = "-"
+			}
+			rows = append(rows, []string{f.Severity, f.ID, f.Installed.Name, f.Installed.Version, fixed, escapeTableCell(f.Summary)})
+		}
+		if len(rows) > 0 {
+			response += "\n\n" + utils.CreateTable("# Findings", []string{"Severity", "ID", "Package", "Installed", "Fixed", "Summary"}, rows)
+		}
+	}
+
+	response += "\n\n---\n\n"
+	rows = [][]string{}
+	for _, p := range result.Packages {
+		rows = append(rows, []string{p.Type, p.Name, p.Version, "/" + p.Location})
+	}
+	if len(rows) > 0 {
+		response += utils.CreateTable("# Packages", []string{"Type", "Name", "Version", "Location"}, rows)
+	} else {
+		response += "## Packages\n\nNo packages detected.\n"
+	}
+
+	return response
+}
+
+func escapeTableCell(value string) string {
+	return strings.ReplaceAll(value, "|", "\\|")
+}
@@ -0,0 +1,65 @@
+package models
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type ImageTag struct {
+	Form
+	imageID string
+	name    string
+}
+
+func NewImageTag(imageID string, name string) ImageTag {
+	return ImageTag{
+		Form: NewForm([]FormField{
+			{Label: "New repository:tag", Placeholder: "registry.example.com/app:1.0"},
+		}),
+		imageID: imageID,
+		name:    name,
+	}
+}
+
+func (it ImageTag) View() string {
+	title := fmt.Sprintf("Tag image: %s", it.name)
+	return it.Form.View(title)
+}
+
+func (it ImageTag) Update(msg tea.Msg, m *model) (ImageTag, tea.Cmd) {
+	if m.currentModel != MImageTag {
+		return it, nil
+	}
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "enter":
+			target := it.Value(0)
+			if target == "" {
+				it.MessageError = "repository:tag is required"
+				return it, nil
+			}
+
+			err := m.dockerClient.ImageTag(it.imageID, target)
+			if err != nil {
+				it.MessageError = err.Error()
+				return it, nil
+			}
+
+			images, err := m.dockerClient.ImageList()
+			if err != nil {
+				fmt.Println(err)
+			}
+
+			m.imageList = NewImageList(images, "")
+			m.currentModel = MImageList
+			return it, tea.ClearScreen
+		}
+	}
+
+	var cmd tea.Cmd
+	it.Form, cmd = it.Form.Update(msg)
+	return it, cmd
+}
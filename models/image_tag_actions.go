@@ -0,0 +1,99 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ernesto27/dcli/docker"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type ImageTagActions struct {
+	Options
+	back currentModel
+}
+
+func NewImageTagActions(tag string, tags []string, containers []docker.MyContainer, back currentModel) ImageTagActions {
+	choices := []string{Push, Tag}
+	disabled := map[string]string{}
+	if len(tags) > 1 {
+		choices = append(choices, Untag)
+	} else {
+		choices = append(choices, Remove)
+		// there is no Force Remove on a single tag, the containers have to be removed first
+		if len(containers) > 0 {
+			names := []string{}
+			for _, c := range containers {
+				names = append(names, c.Name)
+			}
+			disabled[Remove] = "used by containers: " + strings.Join(names, ", ") + ", remove them first"
+		}
+	}
+
+	return ImageTagActions{
+		Options: Options{
+			Cursor:   0,
+			Choice:   "",
+			Choices:  choices,
+			Text1:    tag,
+			Disabled: disabled,
+		},
+		back: back,
+	}
+}
+
+func (o ImageTagActions) View() string {
+	title := fmt.Sprintf("Options tag: %s", o.Text1)
+	return o.Options.View(title)
+}
+
+func (o ImageTagActions) Update(msg tea.Msg, m *model) (ImageTagActions, tea.Cmd) {
+	if m.currentModel != MImageTagActions {
+		return o, nil
+	}
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "enter":
+			if reason := o.disabledReason(); reason != "" {
+				o.MessageError = reason
+				return o, nil
+			}
+
+			switch o.Choices[o.Cursor] {
+			case Push:
+				cmd, err := m.pushImage(o.Text1)
+				if err != nil {
+					o.MessageError = err.Error()
+					return o, nil
+				}
+				return o, cmd
+			case Tag:
+				m.imageTag = NewImageTag(o.Text1, o.Text1)
+				m.currentModel = MImageTag
+				return o, nil
+			case Untag, Remove:
+				err := m.untagImage(o.Text1)
+				if err != nil {
+					o.MessageError = err.Error()
+					return o, nil
+				}
+				return o, tea.ClearScreen
+			}
+		case "down":
+			o.Cursor++
+			if o.Cursor >= len(o.Choices) {
+				o.Cursor = 0
+			}
+		case "up":
+			o.Cursor--
+			if o.Cursor < 0 {
+				o.Cursor = len(o.Choices) - 1
+			}
+		}
+	}
+
+	return o, nil
+}
@@ -0,0 +1,41 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/ernesto27/dcli/docker"
+)
+
+func TestNewImageTagActions(t *testing.T) {
+	tests := []struct {
+		name       string
+		tags       []string
+		containers []docker.MyContainer
+		want       string
+	}{
+		{
+			name: "should remove an image with one tag",
+			tags: []string{"nginx:latest"},
+		},
+		{
+			name:       "should not remove an image used by a stopped container",
+			tags:       []string{"nginx:latest"},
+			containers: []docker.MyContainer{{Name: "web", State: "exited"}},
+			want:       "used by containers: web, remove them first",
+		},
+		{
+			name:       "should untag an image with several tags used by containers",
+			tags:       []string{"nginx:latest", "nginx:1.25"},
+			containers: []docker.MyContainer{{Name: "web", State: "running"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewImageTagActions(tt.tags[0], tt.tags, tt.containers, MImageDetail).Disabled[Remove]
+			if got != tt.want {
+				t.Errorf("NewImageTagActions() Remove disabled = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package models
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type ImageTagOptions struct {
+	Options
+	action  string
+	imageID string
+	back    currentModel
+}
+
+func NewImageTagOptions(action string, image string, imageID string, tags []string, back currentModel) ImageTagOptions {
+	return ImageTagOptions{
+		Options: Options{
+			Cursor:  0,
+			Choice:  "",
+			Choices: tags,
+			Text1:   image,
+		},
+		action:  action,
+		imageID: imageID,
+		back:    back,
+	}
+}
+
+func (o ImageTagOptions) View() string {
+	title := fmt.Sprintf("%s image: %s - select tag", o.action, o.Text1)
+	return o.Options.View(title)
+}
+
+func (o ImageTagOptions) Update(msg tea.Msg, m *model) (ImageTagOptions, tea.Cmd) {
+	if m.currentModel != MImageTagOptions {
+		return o, nil
+	}
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "enter":
+			tag := o.Choices[o.Cursor]
+
+			switch o.action {
+			case Actions:
+				img, err := m.dockerClient.GetImageByID(o.imageID)
+				if err != nil {
+					fmt.Println(err)
+				}
+
+				m.imageTagActions = NewImageTagActions(tag, o.Choices, m.dockerClient.GetImageRelations(img).Containers, MImageTagOptions)
+				m.currentModel = MImageTagActions
+				return o, nil
+			case Push:
+				cmd, err := m.pushImage(tag)
+				if err != nil {
+					o.MessageError = err.Error()
+					return o, nil
+				}
+				return o, cmd
+			case Untag:
+				err := m.untagImage(tag)
+				if err != nil {
+					o.MessageError = err.Error()
+					return o, nil
+				}
+				return o, tea.ClearScreen
+			}
+		case "down":
+			o.Cursor++
+			if o.Cursor >= len(o.Choices) {
+				o.Cursor = 0
+			}
+		case "up":
+			o.Cursor--
+			if o.Cursor < 0 {
+				o.Cursor = len(o.Choices) - 1
+			}
+		}
+	}
+
+	return o, nil
+}
+
+func (m *model) untagImage(tag string) error {
+	err := m.dockerClient.ImageUntag(tag)
+	if err != nil {
+		return err
+	}
+
+	images, err := m.dockerClient.ImageList()
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	m.imageList = NewImageList(images, "")
+	m.currentModel = MImageList
+	return nil
+}
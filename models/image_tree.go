@@ -0,0 +1,59 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ernesto27/dcli/docker"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	"github.com/charmbracelet/lipgloss"
+)
+
+func NewImageTree(images []docker.MyImage, containers []docker.MyContainer, selectedID string) viewport.Model {
+	vp := viewport.New(140, 30)
+	vp.Style = lipgloss.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("62")).
+		PaddingRight(2)
+
+	content := titleTableStyle("IMAGE HIERARCHY") + "\n\n" + renderImageTree(docker.BuildImageTree(images), containers, selectedID)
+	vp.SetContent(content)
+
+	return vp
+}
+
+func renderImageTree(nodes []*docker.MyImageNode, containers []docker.MyContainer, selectedID string) string {
+	s := strings.Builder{}
+
+	var walk func(list []*docker.MyImageNode, prefix string)
+	walk = func(list []*docker.MyImageNode, prefix string) {
+		for index, n := range list {
+			branch, next := "├── ", "│   "
+			if index == len(list)-1 {
+				branch, next = "└── ", "    "
+			}
+
+			used := 0
+			for _, c := range containers {
+				if c.ImageID == n.Image.Inspect.ID {
+					used++
+				}
+			}
+
+			line := fmt.Sprintf("%s (%s) %s • %d layers", n.Image.GetName(), n.Image.GetShortID(), n.Image.GetFormatSize(), len(n.Image.Inspect.RootFS.Layers))
+			if used > 0 {
+				line += fmt.Sprintf(" • used by %d containers", used)
+			}
+			if n.Image.GetID() == selectedID {
+				line = "\033[32m" + line + " ◀\033[0m"
+			}
+
+			s.WriteString(prefix + branch + line + "\n")
+			walk(n.Children, prefix+next)
+		}
+	}
+	walk(nodes, "")
+
+	return s.String()
+}
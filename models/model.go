@@ -2,9 +2,10 @@ package models
 
 import (
 	"fmt"
-	"os/exec"
+	"time"
 
 	"github.com/ernesto27/dcli/docker"
+	"github.com/ernesto27/dcli/utils"
 
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
@@ -13,14 +14,18 @@ import (
 
 const commands = `
  GENERAL ↑/↓: Navigate • ctrl+c: Exit • ctrl+r: refresh • esc: Back 
- CONTAINERS ctrl+f: Search • ctrl+l: Logs • ctrl+o: Options • ctrl+e: Attach cmd • ctrl+s: Stats • ctrl+a: Order by size
- IMAGES ctrl+b: List • ctrl+f: Search • ctrl+o: Options • ctrl+a: Order by size
- NETWORKS ctrl+n: List • ctrl+f: Search  • ctrl+o: Options
+ CONTAINERS ctrl+f: Search • ctrl+l: Logs • ctrl+o: Options • ctrl+e: Exec • ctrl+g: Files • ctrl+s: Stats • ctrl+w: Open url • ctrl+y: Copy • ctrl+u: Only unhealthy • ctrl+a: Order by size
+ IMAGES ctrl+b: List • ctrl+f: Search • ctrl+o: Options (remove, tag, push, untag) • ctrl+t: Layers • ctrl+e: Explore files • ctrl+d: Image tree • ctrl+s: Save • ctrl+l: Load • ctrl+u: Import • ctrl+y: Copy • ctrl+a: Order by size
+ NETWORKS ctrl+n: List • ctrl+a: Create • ctrl+f: Search  • ctrl+o: Options (connect, disconnect, remove)
  VOLUMES ctrl+v: List • ctrl+f: Search  • ctrl+o: Options
    `
 
 var helpStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#9999FF")).Render
 
+// ansiWidth is what bubbles/table counts for the codes of a coloured cell like "\033[31m" + s + "\033[0m",
+// columns with coloured cells reserve it so the reset code is not truncated.
+const ansiWidth = 7
+
 type currentModel int
 
 const (
@@ -31,17 +36,41 @@ const (
 	MContainerOptions
 	MContainerStats
 	MContainerExecOptions
+	MContainerAttach
 	MContainerTop
+	MContainerFiles
+	MContainerCopy
+	MContainerFileView
+	MContainerCommit
+	MContainerExport
+	MContainerResources
+	MContainerStop
+	MContainerKill
+	MContainerRename
 
 	MImageList
 	MImageDetail
 	MImageSearch
 	MImageOptions
+	MImageTag
+	MImageTagOptions
+	MImageSave
+	MImageLoad
+	MImageImport
+	MImageLayers
+	MImageExplorer
+	MImageTree
+	MImageScan
+	MImageScanResult
+	MImageTagActions
 
 	MNetworkList
 	MNetworkSearch
 	MNetworkDetail
 	MNetworkOptions
+	MNetworkCreate
+	MNetworkConnect
+	MNetworkDisconnect
 
 	MVolumeList
 	MVolumeDetail
@@ -50,6 +79,9 @@ const (
 
 	MStackList
 	MStackDetail
+
+	MProgress
+	MValueOptions
 )
 
 type model struct {
@@ -61,21 +93,47 @@ type model struct {
 	containerOptions     ContainerOptions
 	containerStats       viewport.Model
 	containerExecOptions ContainerExecOptions
+	containerAttach      ContainerAttach
 	containerTop         ContainerTop
+	containerFiles       ContainerFiles
+	containerCopy        ContainerCopy
+	containerFileView    ContainerFileView
+	containerCommit      ContainerCommit
+	containerExport      ContainerExport
+	containerResources   ContainerResources
+	containerStop        ContainerStop
+	containerKill        ContainerKill
+	containerRename      ContainerRename
 	imageList            ImageList
-	imageDetail          viewport.Model
+	imageDetail          ImageDetail
 	imageSearch          ImageSearch
 	imageOptions         ImageOptions
+	imageTag             ImageTag
+	imageTagOptions      ImageTagOptions
+	imageTagActions      ImageTagActions
+	imageSave            ImageSave
+	imageLoad            ImageLoad
+	imageImport          ImageImport
+	imageLayers          ImageLayers
+	imageExplorer        ImageExplorer
+	imageTree            viewport.Model
+	imageScan            ImageScan
+	imageScanResult      ImageScanResult
 	networkList          NetworkList
 	networkSearch        NetworkSearch
-	networkDetail        viewport.Model
+	networkDetail        NetworkDetail
 	networkOptions       NetworkOptions
+	networkCreate        NetworkCreate
+	networkConnect       NetworkConnect
+	networkDisconnect    NetworkDisconnect
 	volumeList           VolumeList
 	volumeDetail         viewport.Model
 	volumeSearch         VolumeSearch
 	volumeOptions        VolumeOptions
 	stackList            StackList
 	stackDetail          viewport.Model
+	progress             Progress
+	valueOptions         ValueOptions
 	ready                bool
 	currentModel         currentModel
 	ContainerID          string
@@ -84,7 +142,11 @@ type model struct {
 	widthScreen          int
 	heightScreen         int
 	cpuCores             int
+	alert                string
+	alertID              int
 	ram                  string
+	// unhealthyOnly filters the container list, it is kept when the list is refreshed
+	unhealthyOnly bool
 }
 
 func NewModel(dockerClient *docker.Docker, version string, cpuCores int, ram string) *model {
@@ -107,23 +169,59 @@ func NewModel(dockerClient *docker.Docker, version string, cpuCores int, ram str
 func (m model) Init() tea.Cmd {
 	return tea.Batch(
 		tea.ClearScreen,
+		waitAlert(m.dockerClient.Alerts()),
 	)
 }
 
+const alertDuration = 15 * time.Second
+
+type alertMsg docker.Alert
+
+type clearAlertMsg struct{ id int }
+
+func waitAlert(alerts <-chan docker.Alert) tea.Cmd {
+	return func() tea.Msg {
+		return alertMsg(<-alerts)
+	}
+}
+
 func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
 	var cmds []tea.Cmd
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		// keys are sent to the attached container, detaching is handled by the attach view
+		if m.currentModel == MContainerAttach {
+			break
+		}
+
 		switch msg.String() {
 		case "esc":
-			if m.currentModel == MImageDetail || m.currentModel == MImageOptions {
+			if m.currentModel == MImageDetail || m.currentModel == MImageOptions || m.currentModel == MImageTag ||
+				m.currentModel == MImageSave || m.currentModel == MImageLoad || m.currentModel == MImageImport || m.currentModel == MImageLayers ||
+				m.currentModel == MImageExplorer || m.currentModel == MImageTree {
 				m.currentModel = MImageList
 				return m, tea.ClearScreen
 			}
 
-			if m.currentModel == MNetworkDetail || m.currentModel == MNetworkSearch || m.currentModel == MNetworkOptions {
+			if m.currentModel == MImageTagOptions {
+				m.currentModel = m.imageTagOptions.back
+				return m, tea.ClearScreen
+			}
+
+			if m.currentModel == MImageTagActions {
+				m.currentModel = m.imageTagActions.back
+				return m, tea.ClearScreen
+			}
+
+			if m.currentModel == MImageScan || m.currentModel == MImageScanResult {
+				m.currentModel = MImageDetail
+				return m, tea.ClearScreen
+			}
+
+			if m.currentModel == MNetworkDetail || m.currentModel == MNetworkSearch || m.currentModel == MNetworkOptions ||
+				m.currentModel == MNetworkCreate || m.currentModel == MNetworkConnect || m.currentModel == MNetworkDisconnect {
 				m.currentModel = MNetworkList
 				return m, tea.ClearScreen
 			}
@@ -138,6 +236,26 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m, tea.ClearScreen
 			}
 
+			if m.currentModel == MContainerCopy || m.currentModel == MContainerFileView {
+				m.currentModel = MContainerFiles
+				return m, tea.ClearScreen
+			}
+
+			if m.currentModel == MProgress {
+				m.currentModel = m.progress.back
+				return m, tea.ClearScreen
+			}
+
+			if m.currentModel == MValueOptions {
+				m.currentModel = m.valueOptions.back
+				return m, tea.ClearScreen
+			}
+
+			// the other container views and the lists go back to the container list, the detail handles it
+			if m.currentModel != MContainerDetail {
+				m.setContainerList()
+			}
+
 		case "ctrl+c":
 			return m, tea.Quit
 		case "down":
@@ -164,18 +282,6 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.volumeList = NewVolumeList(volumes, "")
 			m.currentModel = MVolumeList
 
-		case "enter":
-			if m.currentModel == MContainerExecOptions {
-				m.currentModel = MContainerList
-				switch m.containerExecOptions.Choices[m.containerExecOptions.Cursor] {
-				case Bash:
-					return m, attachToContainer(m.containerList.table.SelectedRow()[0], Bash)
-				case Sh:
-					return m, attachToContainer(m.containerList.table.SelectedRow()[0], Sh)
-				case Ash:
-					return m, attachToContainer(m.containerList.table.SelectedRow()[0], Ash)
-				}
-			}
 		case "ctrl+p":
 			stacks, err := m.dockerClient.StackList()
 			if err != nil {
@@ -191,6 +297,19 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.err = msg.err
 		}
 
+	case alertMsg:
+		m.alert = msg.Text
+		m.alertID++
+		id := m.alertID
+		cmds = append(cmds, waitAlert(m.dockerClient.Alerts()), tea.Tick(alertDuration, func(time.Time) tea.Msg {
+			return clearAlertMsg{id: id}
+		}))
+
+	case clearAlertMsg:
+		if msg.id == m.alertID {
+			m.alert = ""
+		}
+
 	case tea.WindowSizeMsg:
 		headerHeight := lipgloss.Height(HeaderView(m.containerLogs.pager, ""))
 		footerHeight := lipgloss.Height(FooterView(m.containerLogs.pager))
@@ -209,22 +328,75 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	}
 
-	m.containerList.table, _ = m.containerList.Update(msg, &m)
-	m.containerDetail.viewport, _ = m.containerDetail.Update(msg, &m)
+	m.containerList.table, cmd = m.containerList.Update(msg, &m)
+	cmds = append(cmds, cmd)
+	m.containerDetail, cmd = m.containerDetail.Update(msg, &m)
+	cmds = append(cmds, cmd)
 	m.containerSearch, _ = m.containerSearch.Update(msg, &m)
-	m.containerOptions, _ = m.containerOptions.Update(msg, &m)
+	m.containerAttach, cmd = m.containerAttach.Update(msg, &m)
+	cmds = append(cmds, cmd)
+	m.containerCommit, cmd = m.containerCommit.Update(msg, &m)
+	cmds = append(cmds, cmd)
+	m.containerExport, cmd = m.containerExport.Update(msg, &m)
+	cmds = append(cmds, cmd)
+	m.containerResources, cmd = m.containerResources.Update(msg, &m)
+	cmds = append(cmds, cmd)
+	m.containerStop, cmd = m.containerStop.Update(msg, &m)
+	cmds = append(cmds, cmd)
+	m.containerKill, cmd = m.containerKill.Update(msg, &m)
+	cmds = append(cmds, cmd)
+	m.containerRename, cmd = m.containerRename.Update(msg, &m)
+	cmds = append(cmds, cmd)
+	m.containerOptions, cmd = m.containerOptions.Update(msg, &m)
+	cmds = append(cmds, cmd)
 	m.containerLogs.pager, _ = m.containerLogs.pager.Update(msg)
-	m.containerExecOptions, _ = m.containerExecOptions.Update(msg, &m)
+	m.containerExecOptions, cmd = m.containerExecOptions.Update(msg, &m)
+	cmds = append(cmds, cmd)
 	m.containerTop, _ = m.containerTop.Update(msg, &m)
+	m.containerCopy, cmd = m.containerCopy.Update(msg, &m)
+	cmds = append(cmds, cmd)
+	m.containerFileView, cmd = m.containerFileView.Update(msg, &m)
+	cmds = append(cmds, cmd)
+	m.containerFiles, cmd = m.containerFiles.Update(msg, &m)
+	cmds = append(cmds, cmd)
 
-	m.imageList.table, _ = m.imageList.Update(msg, &m)
+	m.imageList.table, cmd = m.imageList.Update(msg, &m)
+	cmds = append(cmds, cmd)
 	m.imageSearch, _ = m.imageSearch.Update(msg, &m)
-	m.imageOptions, _ = m.imageOptions.Update(msg, &m)
-	m.imageDetail, _ = m.imageDetail.Update(msg)
+	m.imageTag, cmd = m.imageTag.Update(msg, &m)
+	cmds = append(cmds, cmd)
+	m.imageTagActions, cmd = m.imageTagActions.Update(msg, &m)
+	cmds = append(cmds, cmd)
+	m.imageTagOptions, cmd = m.imageTagOptions.Update(msg, &m)
+	cmds = append(cmds, cmd)
+	m.imageOptions, cmd = m.imageOptions.Update(msg, &m)
+	cmds = append(cmds, cmd)
+	m.imageSave, cmd = m.imageSave.Update(msg, &m)
+	cmds = append(cmds, cmd)
+	m.imageLoad, cmd = m.imageLoad.Update(msg, &m)
+	cmds = append(cmds, cmd)
+	m.imageImport, cmd = m.imageImport.Update(msg, &m)
+	cmds = append(cmds, cmd)
+	m.imageLayers, _ = m.imageLayers.Update(msg, &m)
+	m.imageExplorer, _ = m.imageExplorer.Update(msg, &m)
+	m.imageTree, _ = m.imageTree.Update(msg)
+	m.imageScanResult, _ = m.imageScanResult.Update(msg, &m)
+	m.imageScan, cmd = m.imageScan.Update(msg, &m)
+	cmds = append(cmds, cmd)
+	m.imageDetail, _ = m.imageDetail.Update(msg, &m)
 
 	m.networkList.table, _ = m.networkList.Update(msg, &m)
+	m.networkDetail, cmd = m.networkDetail.Update(msg, &m)
+	cmds = append(cmds, cmd)
 	m.networkSearch, _ = m.networkSearch.Update(msg, &m)
-	m.networkOptions, _ = m.networkOptions.Update(msg, &m)
+	m.networkCreate, cmd = m.networkCreate.Update(msg, &m)
+	cmds = append(cmds, cmd)
+	m.networkConnect, cmd = m.networkConnect.Update(msg, &m)
+	cmds = append(cmds, cmd)
+	m.networkDisconnect, cmd = m.networkDisconnect.Update(msg, &m)
+	cmds = append(cmds, cmd)
+	m.networkOptions, cmd = m.networkOptions.Update(msg, &m)
+	cmds = append(cmds, cmd)
 
 	m.volumeList.table, _ = m.volumeList.Update(msg, &m)
 	m.volumeDetail, _ = m.volumeDetail.Update(msg)
@@ -234,7 +406,11 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	m.stackList.table, _ = m.stackList.Update(msg, &m)
 	m.stackDetail, _ = m.stackDetail.Update(msg)
 
+	m.progress, cmd = m.progress.Update(msg, &m)
+	cmds = append(cmds, cmd)
+	m.valueOptions, cmd = m.valueOptions.Update(msg, &m)
 	cmds = append(cmds, cmd)
+
 	return m, tea.Batch(cmds...)
 }
 
@@ -264,16 +440,23 @@ func (m model) View() string {
 			BorderForeground(lipgloss.Color("#F1ECEB")).
 			Foreground(lipgloss.Color("#FC765B"))
 
-		errorText := ""
-		if m.err.Error() == "exit status 1" {
-			errorText = "Container is not running"
-		} else {
-			errorText = "OCI runtime exec failed: exec failed: unable to start container process: exec"
-		}
+		return errorStyle.Render("Error: " + m.err.Error() + " \n\nEsc to go back")
+	}
 
-		return errorStyle.Render("Error: " + errorText + " \n\nEsc to go back")
+	if m.alert != "" {
+		return alertStyle.Render("⚠ "+m.alert) + "\n" + m.currentView()
 	}
+	return m.currentView()
+}
+
+var alertStyle = lipgloss.NewStyle().
+	MarginLeft(1).
+	Padding(0, 1).
+	Bold(true).
+	Background(lipgloss.Color("#FC765B")).
+	Foreground(lipgloss.Color("#FFF"))
 
+func (m model) currentView() string {
 	switch m.currentModel {
 	case MContainerList:
 		return m.containerList.View(commands, &m)
@@ -287,10 +470,30 @@ func (m model) View() string {
 		return m.containerOptions.View()
 	case MContainerStats:
 		return m.containerStats.View()
+	case MContainerAttach:
+		return m.containerAttach.View()
 	case MContainerExecOptions:
 		return m.containerExecOptions.View()
 	case MContainerTop:
 		return m.containerTop.View()
+	case MContainerFiles:
+		return m.containerFiles.View()
+	case MContainerCopy:
+		return m.containerCopy.View()
+	case MContainerFileView:
+		return m.containerFileView.View()
+	case MContainerCommit:
+		return m.containerCommit.View()
+	case MContainerExport:
+		return m.containerExport.View()
+	case MContainerResources:
+		return m.containerResources.View()
+	case MContainerStop:
+		return m.containerStop.View()
+	case MContainerKill:
+		return m.containerKill.View()
+	case MContainerRename:
+		return m.containerRename.View()
 
 	case MImageList:
 		return m.imageList.View(commands, &m)
@@ -300,6 +503,31 @@ func (m model) View() string {
 		return m.imageDetail.View()
 	case MImageSearch:
 		return m.imageSearch.View()
+	case MImageTag:
+		return m.imageTag.View()
+	case MImageTagOptions:
+		return m.imageTagOptions.View()
+	case MImageSave:
+		return m.imageSave.View()
+	case MImageLoad:
+		return m.imageLoad.View()
+	case MImageImport:
+		return m.imageImport.View()
+	case MImageLayers:
+		return m.imageLayers.View()
+	case MImageExplorer:
+		return m.imageExplorer.View()
+	case MImageTree:
+		return m.imageTree.View() + helpStyle("\n  ↑/↓: Navigate • Esc: back\n")
+
+	case MImageTagActions:
+		return m.imageTagActions.View()
+
+	case MImageScan:
+		return m.imageScan.View()
+
+	case MImageScanResult:
+		return m.imageScanResult.View()
 
 	case MNetworkList:
 		return m.networkList.View(commands, &m)
@@ -309,6 +537,12 @@ func (m model) View() string {
 		return m.networkDetail.View()
 	case MNetworkOptions:
 		return m.networkOptions.View()
+	case MNetworkCreate:
+		return m.networkCreate.View()
+	case MNetworkConnect:
+		return m.networkConnect.View()
+	case MNetworkDisconnect:
+		return m.networkDisconnect.View()
 
 	case MVolumeList:
 		return m.volumeList.View(commands, &m)
@@ -324,6 +558,12 @@ func (m model) View() string {
 	case MStackDetail:
 		return m.stackDetail.View()
 
+	case MProgress:
+		return m.progress.View()
+
+	case MValueOptions:
+		return m.valueOptions.View()
+
 	default:
 		return ""
 
@@ -332,24 +572,54 @@ func (m model) View() string {
 
 type attachExited struct{ err error }
 
-func attachToContainer(ID string, command string) tea.Cmd {
-	c := exec.Command("docker", "exec", "-it", ID, command)
-	return tea.ExecProcess(c, func(err error) tea.Msg {
-		return attachExited{err}
-	})
+func (m *model) setContainerList() {
+	m.refreshContainerList()
+	m.currentModel = MContainerList
 }
 
-func (m *model) setContainerList() {
+// refreshContainerList reloads the rows of the container list without leaving the current view.
+func (m *model) refreshContainerList() {
 	var err error
 	_, err = m.dockerClient.ContainerList()
 	if err != nil {
 		fmt.Println(err)
 	}
 
-	t := NewContainerList(GetContainerRows(m.dockerClient.Containers, ""))
+	containers := m.dockerClient.Containers
+	t := NewContainerList(GetContainerRows(containers, ""))
+	if m.unhealthyOnly {
+		t = NewContainerList(GetContainerRows(filterUnhealthy(containers), ""))
+		t.title = "CONTAINERS (unhealthy)"
+	}
 	m.err = nil
 	m.containerList = t
-	m.currentModel = MContainerList
+}
+
+// setNetworkDetail reloads the containers and networks and shows the detail of the network.
+func (m *model) setNetworkDetail(name string) {
+	if _, err := m.dockerClient.ContainerList(); err != nil {
+		fmt.Println(err)
+	}
+
+	networks, err := m.dockerClient.NetworkList()
+	if err != nil {
+		fmt.Println(err)
+	}
+	m.networkList = NewNetworkList(networks, "")
+
+	network, err := m.dockerClient.GetNetworkByName(name)
+	if err != nil {
+		fmt.Println(err)
+		m.currentModel = MNetworkList
+		return
+	}
+
+	nd, err := NewNetworkDetail(network, utils.CreateTable)
+	if err != nil {
+		fmt.Println(err)
+	}
+	m.networkDetail = nd
+	m.currentModel = MNetworkDetail
 }
 
 func (m *model) getDockerStats() string {
@@ -0,0 +1,68 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type NetworkConnect struct {
+	Form
+	networkID string
+	network   string
+}
+
+func NewNetworkConnect(networkID string, network string) NetworkConnect {
+	return NetworkConnect{
+		Form: NewForm([]FormField{
+			{Label: "Container name or ID", Placeholder: "web"},
+			{Label: "Aliases (separated by ,, optional)", Placeholder: "api, api.internal"},
+			{Label: "IP address (optional, only for networks with a subnet set by the user)", Placeholder: "172.28.5.10"},
+		}),
+		networkID: networkID,
+		network:   network,
+	}
+}
+
+func (nc NetworkConnect) View() string {
+	title := fmt.Sprintf("Connect container to network: %s", nc.network)
+	return nc.Form.View(title)
+}
+
+func (nc NetworkConnect) Update(msg tea.Msg, m *model) (NetworkConnect, tea.Cmd) {
+	if m.currentModel != MNetworkConnect {
+		return nc, nil
+	}
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "enter":
+			container := nc.Value(0)
+			if container == "" {
+				nc.MessageError = "container is required"
+				return nc, nil
+			}
+
+			aliases := []string{}
+			for _, alias := range strings.Split(nc.Value(1), ",") {
+				if alias = strings.TrimSpace(alias); alias != "" {
+					aliases = append(aliases, alias)
+				}
+			}
+
+			if err := m.dockerClient.NetworkConnect(nc.networkID, container, aliases, nc.Value(2)); err != nil {
+				nc.MessageError = err.Error()
+				return nc, nil
+			}
+
+			m.setNetworkDetail(nc.network)
+			return nc, tea.ClearScreen
+		}
+	}
+
+	var cmd tea.Cmd
+	nc.Form, cmd = nc.Form.Update(msg)
+	return nc, cmd
+}
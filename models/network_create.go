@@ -0,0 +1,122 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ernesto27/dcli/docker"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type NetworkCreate struct {
+	Form
+}
+
+func NewNetworkCreate() NetworkCreate {
+	return NetworkCreate{
+		Form: NewForm([]FormField{
+			{Label: "Name", Placeholder: "backend"},
+			{Label: "Driver", Placeholder: "bridge", Value: "bridge"},
+			{Label: "Subnet (optional)", Placeholder: "172.28.0.0/16"},
+			{Label: "Gateway (optional, needs a subnet)", Placeholder: "172.28.0.1"},
+			{Label: "IP range (optional, needs a subnet)", Placeholder: "172.28.5.0/24"},
+			{Label: "Internal, no access to external networks (yes/no)", Placeholder: "no", Value: "no"},
+			{Label: "Attachable by standalone containers, overlay only (yes/no)", Placeholder: "no", Value: "no"},
+			{Label: "Labels (key=value separated by ,, optional)", Placeholder: "env=dev, team=backend"},
+		}),
+	}
+}
+
+func (nc NetworkCreate) View() string {
+	return nc.Form.View("Create network")
+}
+
+func (nc NetworkCreate) Update(msg tea.Msg, m *model) (NetworkCreate, tea.Cmd) {
+	if m.currentModel != MNetworkCreate {
+		return nc, nil
+	}
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "enter":
+			options, err := nc.options()
+			if err != nil {
+				nc.MessageError = err.Error()
+				return nc, nil
+			}
+
+			if _, err := m.dockerClient.NetworkCreate(options); err != nil {
+				nc.MessageError = err.Error()
+				return nc, nil
+			}
+
+			m.setNetworkDetail(options.Name)
+			return nc, tea.ClearScreen
+		}
+	}
+
+	var cmd tea.Cmd
+	nc.Form, cmd = nc.Form.Update(msg)
+	return nc, cmd
+}
+
+func (nc NetworkCreate) options() (docker.NetworkCreateOptions, error) {
+	options := docker.NetworkCreateOptions{
+		Name:    nc.Value(0),
+		Driver:  nc.Value(1),
+		Subnet:  nc.Value(2),
+		Gateway: nc.Value(3),
+		IPRange: nc.Value(4),
+	}
+
+	if options.Name == "" {
+		return options, fmt.Errorf("name is required")
+	}
+	if options.Subnet == "" && (options.Gateway != "" || options.IPRange != "") {
+		return options, fmt.Errorf("a subnet is required to set the gateway or the IP range")
+	}
+
+	var err error
+	if options.Internal, err = parseYesNo("internal", nc.Value(5)); err != nil {
+		return options, err
+	}
+	if options.Attachable, err = parseYesNo("attachable", nc.Value(6)); err != nil {
+		return options, err
+	}
+	if options.Labels, err = parseLabels(nc.Value(7)); err != nil {
+		return options, err
+	}
+
+	return options, nil
+}
+
+func parseYesNo(label string, value string) (bool, error) {
+	switch strings.ToLower(value) {
+	case "", "no", "n", "false":
+		return false, nil
+	case "yes", "y", "true":
+		return true, nil
+	}
+	return false, fmt.Errorf("invalid %s: %s, use yes or no", label, value)
+}
+
+// parseLabels parses labels like env=dev, team=backend, a label without value is set to an empty string.
+func parseLabels(value string) (map[string]string, error) {
+	labels := map[string]string{}
+	for _, label := range strings.Split(value, ",") {
+		label = strings.TrimSpace(label)
+		if label == "" {
+			continue
+		}
+
+		key, val, _ := strings.Cut(label, "=")
+		key = strings.TrimSpace(key)
+		if key == "" {
+			return nil, fmt.Errorf("invalid label: %s", label)
+		}
+		labels[key] = strings.TrimSpace(val)
+	}
+	return labels, nil
+}
@@ -0,0 +1,32 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseLabels(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    map[string]string
+		wantErr bool
+	}{
+		{value: "", want: map[string]string{}},
+		{value: "env=dev, team = backend", want: map[string]string{"env": "dev", "team": "backend"}},
+		{value: "traefik.enable", want: map[string]string{"traefik.enable": ""}},
+		{value: "=dev", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := parseLabels(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseLabels() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseLabels() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
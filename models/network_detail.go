@@ -1,16 +1,24 @@
 package models
 
 import (
+	"strings"
+
 	"github.com/ernesto27/dcli/utils"
 
 	"github.com/ernesto27/dcli/docker"
 
 	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/glamour"
 	"github.com/charmbracelet/lipgloss"
 )
 
-func NewNetworkDetail(network docker.MyNetwork, createTable utils.CreateTableFunc) (viewport.Model, error) {
+type NetworkDetail struct {
+	viewport viewport.Model
+	network  docker.MyNetwork
+}
+
+func NewNetworkDetail(network docker.MyNetwork, createTable utils.CreateTableFunc) (NetworkDetail, error) {
 	content := getContentNetwork(network)
 	const width = 120
 
@@ -25,17 +33,41 @@ func NewNetworkDetail(network docker.MyNetwork, createTable utils.CreateTableFun
 		glamour.WithWordWrap(width),
 	)
 	if err != nil {
-		return viewport.Model{}, err
+		return NetworkDetail{}, err
 	}
 
 	str, err := renderer.Render(content)
 	if err != nil {
-		return viewport.Model{}, err
+		return NetworkDetail{}, err
 	}
 
 	vp.SetContent(str)
 
-	return vp, nil
+	return NetworkDetail{viewport: vp, network: network}, nil
+}
+
+func (nd NetworkDetail) View() string {
+	return nd.viewport.View() + helpStyle("\n  ↑/↓: Navigate • ctrl+o: Options (connect, disconnect, remove) • Esc: back to list\n")
+}
+
+func (nd NetworkDetail) Update(msg tea.Msg, m *model) (NetworkDetail, tea.Cmd) {
+	if m.currentModel != MNetworkDetail {
+		return nd, nil
+	}
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+o":
+			m.networkOptions = NewNetworkOptions(nd.network)
+			m.currentModel = MNetworkOptions
+			return nd, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	nd.viewport, cmd = nd.viewport.Update(msg)
+	return nd, cmd
 }
 
 func getContentNetwork(network docker.MyNetwork) string {
@@ -46,22 +78,36 @@ func getContentNetwork(network docker.MyNetwork) string {
 		attachable = "true"
 	}
 
+	internal := "false"
+	if network.Resource.Internal {
+		internal = "true"
+	}
+
 	response += utils.CreateTable("# Network status", []string{"Type", "Value"},
 		[][]string{
 			{"ID", network.Resource.ID},
 			{"Name", network.Resource.Name},
 			{"Driver", network.Resource.Driver},
 			{"Attachable", attachable},
+			{"Internal", internal},
 			{"Subnet", network.Subnet},
 			{"Gateway", network.Gateway},
 		})
 
 	if len(network.Containers) > 0 {
-		columns := []string{"Name", "IPv4 Address"}
+		columns := []string{"Name", "IPv4 Address", "Aliases"}
 		rows := [][]string{}
 
 		for _, c := range network.Containers {
-			rows = append(rows, []string{c.Name, c.Network.IPAddress})
+			ip := c.Network.IPAddress
+			aliases := ""
+			for _, e := range c.Networks {
+				if e.Name == network.Resource.Name {
+					ip = e.IPAddress
+					aliases = strings.Join(e.Aliases, ", ")
+				}
+			}
+			rows = append(rows, []string{c.Name, ip, aliases})
 		}
 
 		response += "\n\n"
@@ -0,0 +1,65 @@
+package models
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type NetworkDisconnect struct {
+	Options
+	networkID string
+}
+
+func NewNetworkDisconnect(networkID string, network string, containers []string) NetworkDisconnect {
+	return NetworkDisconnect{
+		Options: Options{
+			Cursor:  0,
+			Choice:  "",
+			Choices: containers,
+			Text1:   network,
+		},
+		networkID: networkID,
+	}
+}
+
+func (o NetworkDisconnect) View() string {
+	title := fmt.Sprintf("Disconnect container from network: %s", o.Text1)
+	return o.Options.View(title)
+}
+
+func (o NetworkDisconnect) Update(msg tea.Msg, m *model) (NetworkDisconnect, tea.Cmd) {
+	if m.currentModel != MNetworkDisconnect {
+		return o, nil
+	}
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "enter":
+			if len(o.Choices) == 0 {
+				return o, nil
+			}
+
+			if err := m.dockerClient.NetworkDisconnect(o.networkID, o.Choices[o.Cursor], false); err != nil {
+				o.MessageError = err.Error()
+				return o, nil
+			}
+
+			m.setNetworkDetail(o.Text1)
+			return o, tea.ClearScreen
+		case "down":
+			o.Cursor++
+			if o.Cursor >= len(o.Choices) {
+				o.Cursor = 0
+			}
+		case "up":
+			o.Cursor--
+			if o.Cursor < 0 {
+				o.Cursor = len(o.Choices) - 1
+			}
+		}
+	}
+
+	return o, nil
+}
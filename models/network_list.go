@@ -82,11 +82,25 @@ func (cl NetworkList) Update(msg tea.Msg, m *model) (table.Model, tea.Cmd) {
 				m.networkDetail = nd
 				m.currentModel = MNetworkDetail
 			}
+		case "ctrl+a":
+			// not ctrl+n, the key press that opens this list from the container list reaches it too
+			m.networkCreate = NewNetworkCreate()
+			m.currentModel = MNetworkCreate
 		case "ctrl+f":
 			m.networkSearch.textInput.SetValue("")
 			m.currentModel = MNetworkSearch
 		case "ctrl+o":
-			m.networkOptions = NewNetworkOptions(m.networkList.table.SelectedRow()[1])
+			if len(m.networkList.table.SelectedRow()) == 0 {
+				return cl.table, nil
+			}
+
+			network, err := m.dockerClient.GetNetworkByName(m.networkList.table.SelectedRow()[1])
+			if err != nil {
+				m.err = err
+				return cl.table, nil
+			}
+
+			m.networkOptions = NewNetworkOptions(network)
 			m.currentModel = MNetworkOptions
 		}
 	}
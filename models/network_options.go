@@ -2,25 +2,70 @@ package models
 
 import (
 	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ernesto27/dcli/docker"
 
 	tea "github.com/charmbracelet/bubbletea"
 )
 
+var networkChoices = []string{Connect, Disconnect, Remove}
+
 type NetworkOptions struct {
 	Options
+	networkID  string
+	containers []string
 }
 
-func NewNetworkOptions(name string) NetworkOptions {
-	choices := []string{Remove}
-
+func NewNetworkOptions(network docker.MyNetwork) NetworkOptions {
 	return NetworkOptions{
-		Options{
-			Cursor:  0,
-			Choice:  "",
-			Choices: choices,
-			Text1:   name,
+		Options: Options{
+			Cursor:   0,
+			Choice:   "",
+			Choices:  networkChoices,
+			Text1:    network.Resource.Name,
+			Disabled: networkDisabledChoices(network),
 		},
+		networkID:  network.Resource.ID,
+		containers: networkContainers(network),
+	}
+}
+
+// networkContainers returns the names of the containers connected to the network, sorted.
+func networkContainers(network docker.MyNetwork) []string {
+	names := []string{}
+	for _, endpoint := range network.Resource.Containers {
+		names = append(names, endpoint.Name)
 	}
+	sort.Strings(names)
+	return names
+}
+
+func networkDisabledChoices(network docker.MyNetwork) map[string]string {
+	disabled := map[string]string{}
+
+	switch network.Resource.Name {
+	case "host", "none":
+		disabled[Connect] = fmt.Sprintf("containers can only use the %s network when they are created", network.Resource.Name)
+		disabled[Disconnect] = disabled[Connect]
+	}
+
+	names := networkContainers(network)
+	if len(names) == 0 {
+		disabled[Disconnect] = "no containers connected"
+	}
+
+	switch network.Resource.Name {
+	case "bridge", "host", "none":
+		disabled[Remove] = "predefined networks can not be removed"
+	default:
+		if len(names) > 0 {
+			disabled[Remove] = "containers connected: " + strings.Join(names, ", ") + ", disconnect them first"
+		}
+	}
+
+	return disabled
 }
 
 func (n NetworkOptions) View() string {
@@ -37,18 +82,24 @@ func (n NetworkOptions) Update(msg tea.Msg, m *model) (NetworkOptions, tea.Cmd)
 	case tea.KeyMsg:
 		switch msg.String() {
 		case "enter":
-			errAction := false
-			option := m.networkOptions.Choices[m.imageOptions.Cursor]
+			if reason := n.disabledReason(); reason != "" {
+				n.MessageError = reason
+				return n, nil
+			}
 
-			if option == Remove {
-				err := m.dockerClient.NetworkRemove(m.networkList.table.SelectedRow()[0])
-				if err != nil {
-					fmt.Println(err)
-					errAction = true
+			switch n.Choices[n.Cursor] {
+			case Connect:
+				m.networkConnect = NewNetworkConnect(n.networkID, n.Text1)
+				m.currentModel = MNetworkConnect
+			case Disconnect:
+				m.networkDisconnect = NewNetworkDisconnect(n.networkID, n.Text1, n.containers)
+				m.currentModel = MNetworkDisconnect
+			case Remove:
+				if err := m.dockerClient.NetworkRemove(n.networkID); err != nil {
+					n.MessageError = err.Error()
+					return n, nil
 				}
-			}
 
-			if !errAction {
 				networks, err := m.dockerClient.NetworkList()
 				if err != nil {
 					fmt.Println(err)
@@ -57,7 +108,17 @@ func (n NetworkOptions) Update(msg tea.Msg, m *model) (NetworkOptions, tea.Cmd)
 				m.networkList = NewNetworkList(networks, "")
 				m.currentModel = MNetworkList
 			}
-
+			return n, tea.ClearScreen
+		case "down":
+			n.Cursor++
+			if n.Cursor >= len(n.Choices) {
+				n.Cursor = 0
+			}
+		case "up":
+			n.Cursor--
+			if n.Cursor < 0 {
+				n.Cursor = len(n.Choices) - 1
+			}
 		}
 	}
 
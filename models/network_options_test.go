@@ -0,0 +1,74 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/ernesto27/dcli/docker"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/docker/docker/api/types"
+)
+
+func TestNetworkDisabledChoices(t *testing.T) {
+	connected := map[string]types.EndpointResource{"abc": {Name: "web"}, "def": {Name: "api"}}
+
+	tests := []struct {
+		name     string
+		network  types.NetworkResource
+		disabled []string
+	}{
+		{
+			name:     "should not remove user networks with containers connected",
+			network:  types.NetworkResource{Name: "backend", Containers: connected},
+			disabled: []string{Remove},
+		},
+		{
+			name:     "should not disconnect from networks without containers",
+			network:  types.NetworkResource{Name: "backend"},
+			disabled: []string{Disconnect},
+		},
+		{
+			name:     "should not remove the bridge network",
+			network:  types.NetworkResource{Name: "bridge", Containers: connected},
+			disabled: []string{Remove},
+		},
+		{
+			name:     "should not connect, disconnect or remove the host network",
+			network:  types.NetworkResource{Name: "host"},
+			disabled: []string{Connect, Disconnect, Remove},
+		},
+		{
+			name:     "should not connect, disconnect or remove the none network",
+			network:  types.NetworkResource{Name: "none", Containers: connected},
+			disabled: []string{Connect, Disconnect, Remove},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := networkDisabledChoices(docker.MyNetwork{Resource: tt.network})
+			if len(got) != len(tt.disabled) {
+				t.Errorf("networkDisabledChoices() = %v, want %v disabled", got, tt.disabled)
+			}
+			for _, choice := range tt.disabled {
+				if _, ok := got[choice]; !ok {
+					t.Errorf("networkDisabledChoices() %s enabled, want disabled", choice)
+				}
+			}
+		})
+	}
+}
+
+func TestNetworkListOptionsNotFound(t *testing.T) {
+	networks := []docker.MyNetwork{{Resource: types.NetworkResource{ID: "abc", Name: "web"}}}
+	m := model{currentModel: MNetworkList, dockerClient: &docker.Docker{}}
+	m.networkList = NewNetworkList(networks, "")
+
+	m.networkList.Update(tea.KeyMsg{Type: tea.KeyCtrlO}, &m)
+	if m.currentModel != MNetworkList {
+		t.Errorf("Update() currentModel = %v, want %v", m.currentModel, MNetworkList)
+	}
+	if m.err == nil {
+		t.Errorf("Update() err = nil, want the network not found error")
+	}
+}
@@ -13,6 +13,8 @@ type Options struct {
 	Text1        string
 	Text2        string
 	MessageError string
+	// Disabled holds the choices that can not be used and the reason
+	Disabled map[string]string
 }
 
 const (
@@ -23,6 +25,18 @@ const (
 	Restart     = "Restart"
 	Pause       = "Pause"
 	Unpause     = "Unpause"
+	Tag         = "Tag"
+	Push        = "Push"
+	Untag       = "Untag"
+	Actions     = "Actions"
+	Attach      = "Attach"
+	Commit      = "Commit"
+	Export      = "Export"
+	Resources   = "Resources"
+	Kill        = "Kill"
+	Rename      = "Rename"
+	Connect     = "Connect container"
+	Disconnect  = "Disconnect container"
 )
 
 func (o Options) View(title string) string {
@@ -44,9 +58,23 @@ func (o Options) View(title string) string {
 		} else {
 			s.WriteString("( ) ")
 		}
-		s.WriteString(o.Choices[i])
+		if reason, ok := o.Disabled[o.Choices[i]]; ok {
+			s.WriteString(disabledStyle.Render(o.Choices[i] + " - " + reason))
+		} else {
+			s.WriteString(o.Choices[i])
+		}
 		s.WriteString("\n")
 	}
 	s.WriteString("\n(press Esc to go back)\n")
 	return style.Render(title) + s.String() + "\n" + o.MessageError
 }
+
+var disabledStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
+
+// disabledReason returns why the selected choice can not be used, or an empty string.
+func (o Options) disabledReason() string {
+	if o.Cursor >= len(o.Choices) {
+		return ""
+	}
+	return o.Disabled[o.Choices[o.Cursor]]
+}
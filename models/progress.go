@@ -0,0 +1,193 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/ernesto27/dcli/utils"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/docker/docker/pkg/jsonmessage"
+)
+
+type progressUpdate struct {
+	// updates with the same id replace the previous line, like the layers on a docker push
+	id   string
+	text string
+	err  error
+}
+
+type progressMsg struct {
+	updates <-chan progressUpdate
+	update  progressUpdate
+	done    bool
+}
+
+type Progress struct {
+	viewport viewport.Model
+	title    string
+	back     currentModel
+	updates  <-chan progressUpdate
+	lines    []string
+	ids      map[string]int
+	done     bool
+	err      error
+	onDone   func(m *model) tea.Cmd
+}
+
+func NewProgress(title string, back currentModel, updates <-chan progressUpdate, onDone func(m *model) tea.Cmd) Progress {
+	vp := viewport.New(120, 25)
+	vp.Style = lipgloss.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("62")).
+		PaddingRight(2)
+
+	return Progress{
+		viewport: vp,
+		title:    title,
+		back:     back,
+		updates:  updates,
+		ids:      map[string]int{},
+		onDone:   onDone,
+	}
+}
+
+// onDone runs when the updates channel is closed, its command is returned to the program.
+func (m *model) startProgress(title string, back currentModel, updates <-chan progressUpdate, onDone func(m *model) tea.Cmd) tea.Cmd {
+	m.progress = NewProgress(title, back, updates, onDone)
+	m.currentModel = MProgress
+	return tea.Batch(tea.ClearScreen, waitProgress(m.progress.updates))
+}
+
+func waitProgress(updates <-chan progressUpdate) tea.Cmd {
+	return func() tea.Msg {
+		u, ok := <-updates
+		return progressMsg{updates: updates, update: u, done: !ok}
+	}
+}
+
+func (p Progress) View() string {
+	status := "In progress..."
+	if p.err != nil {
+		status = "Error: " + p.err.Error()
+	} else if p.done {
+		status = "Done"
+	}
+
+	return titleTableStyle(p.title) + "\n\n" + p.viewport.View() + "\n" + status + helpStyle("\n  ↑/↓: Navigate • Esc: back\n")
+}
+
+func (p Progress) Update(msg tea.Msg, m *model) (Progress, tea.Cmd) {
+	switch msg := msg.(type) {
+	case progressMsg:
+		// the updates of a previous operation are drained so its goroutine can finish and close the body
+		if msg.updates != p.updates {
+			if msg.done {
+				return p, nil
+			}
+			return p, waitProgress(msg.updates)
+		}
+
+		if msg.done {
+			p.done = true
+			if p.onDone != nil {
+				return p, p.onDone(m)
+			}
+			return p, nil
+		}
+
+		if msg.update.err != nil {
+			p.err = msg.update.err
+		}
+
+		if msg.update.text != "" {
+			if index, ok := p.ids[msg.update.id]; ok {
+				p.lines[index] = msg.update.text
+			} else {
+				if msg.update.id != "" {
+					p.ids[msg.update.id] = len(p.lines)
+				}
+				p.lines = append(p.lines, msg.update.text)
+			}
+			p.viewport.SetContent(strings.Join(p.lines, "\n"))
+			p.viewport.GotoBottom()
+		}
+
+		return p, waitProgress(p.updates)
+	}
+
+	if m.currentModel == MProgress {
+		p.viewport, _ = p.viewport.Update(msg)
+	}
+
+	return p, nil
+}
+
+// jsonMessages decodes a docker json message stream like the ones returned by push, load or import.
+func jsonMessages(body io.ReadCloser) <-chan progressUpdate {
+	updates := make(chan progressUpdate)
+
+	go func() {
+		defer close(updates)
+		defer body.Close()
+
+		decoder := json.NewDecoder(body)
+		for {
+			var jm jsonmessage.JSONMessage
+			if err := decoder.Decode(&jm); err != nil {
+				if err != io.EOF {
+					updates <- progressUpdate{err: err}
+				}
+				return
+			}
+
+			if jm.Error != nil {
+				updates <- progressUpdate{err: jm.Error}
+				return
+			}
+
+			text := strings.TrimSpace(jm.Stream)
+			if jm.Status != "" {
+				text = strings.TrimSpace(jm.Status + " " + jm.ProgressMessage)
+			}
+			if jm.ID != "" {
+				text = jm.ID + ": " + text
+			}
+
+			updates <- progressUpdate{id: jm.ID, text: text}
+		}
+	}()
+
+	return updates
+}
+
+// progressWriter reports the bytes written through it, at most a few times per second.
+type progressWriter struct {
+	updates chan<- progressUpdate
+	label   string
+	total   int64
+	written int64
+	last    time.Time
+}
+
+func (pw *progressWriter) Write(p []byte) (int, error) {
+	pw.written += int64(len(p))
+	if time.Since(pw.last) > 200*time.Millisecond {
+		pw.last = time.Now()
+		pw.report()
+	}
+	return len(p), nil
+}
+
+func (pw *progressWriter) report() {
+	text := fmt.Sprintf("%s: %s", pw.label, utils.FormatSize(pw.written))
+	if pw.total > 0 {
+		text += fmt.Sprintf(" / ~%s", utils.FormatSize(pw.total))
+	}
+	pw.updates <- progressUpdate{id: pw.label, text: text}
+}
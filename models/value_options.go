@@ -0,0 +1,141 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ernesto27/dcli/docker"
+	"github.com/ernesto27/dcli/utils"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type valueItem struct {
+	label string
+	value string
+}
+
+// ValueOptions lists values of a container or image to copy them to the clipboard or open them in the browser.
+type ValueOptions struct {
+	Options
+	values []string
+	open   bool
+	back   currentModel
+}
+
+func NewValueOptions(title string, items []valueItem, open bool, back currentModel) ValueOptions {
+	choices := []string{}
+	values := []string{}
+	for _, item := range items {
+		choices = append(choices, item.label+": "+utils.TrimValue(item.value, 60))
+		values = append(values, item.value)
+	}
+
+	return ValueOptions{
+		Options: Options{
+			Choices: choices,
+			Text1:   title,
+		},
+		values: values,
+		open:   open,
+		back:   back,
+	}
+}
+
+func (o ValueOptions) View() string {
+	title := fmt.Sprintf("Copy to clipboard: %s", o.Text1)
+	if o.open {
+		title = fmt.Sprintf("Open in browser: %s", o.Text1)
+	}
+	return o.Options.View(title)
+}
+
+func (o ValueOptions) Update(msg tea.Msg, m *model) (ValueOptions, tea.Cmd) {
+	if m.currentModel != MValueOptions {
+		return o, nil
+	}
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "enter":
+			if len(o.values) == 0 {
+				return o, nil
+			}
+			value := o.values[o.Cursor]
+
+			if o.open {
+				if err := utils.OpenURL(value); err != nil {
+					o.MessageError = fmt.Sprintf("%s, open %s manually", err.Error(), value)
+					return o, nil
+				}
+				m.currentModel = o.back
+				return o, tea.ClearScreen
+			}
+
+			err := utils.CopyToClipboard(value)
+			switch {
+			case errors.Is(err, utils.ErrNoClipboard):
+				o.MessageError = "No clipboard available, value:\n\n" + value
+			case err != nil:
+				o.MessageError = err.Error()
+			default:
+				o.MessageError = "Copied to the clipboard"
+			}
+		case "down":
+			o.Cursor++
+			if o.Cursor >= len(o.Choices) {
+				o.Cursor = 0
+			}
+			o.MessageError = ""
+		case "up":
+			o.Cursor--
+			if o.Cursor < 0 {
+				o.Cursor = len(o.Choices) - 1
+			}
+			o.MessageError = ""
+		}
+	}
+
+	return o, nil
+}
+
+func containerValues(c docker.MyContainer) []valueItem {
+	items := []valueItem{
+		{label: "ID", value: c.ID},
+		{label: "Name", value: c.Name},
+		{label: "Image", value: c.Image},
+	}
+
+	for _, e := range c.Networks {
+		if e.IPAddress != "" {
+			items = append(items, valueItem{label: "IP " + e.Name, value: e.IPAddress})
+		}
+		if e.IPv6Address != "" {
+			items = append(items, valueItem{label: "IPv6 " + e.Name, value: e.IPv6Address})
+		}
+	}
+
+	for _, url := range docker.PublishedURLs(c.Ports) {
+		items = append(items, valueItem{label: "URL", value: url})
+	}
+
+	for _, env := range c.Env {
+		name, value, _ := strings.Cut(env, "=")
+		items = append(items, valueItem{label: "Env " + name, value: value})
+	}
+
+	return items
+}
+
+func imageValues(img docker.MyImage) []valueItem {
+	items := []valueItem{{label: "ID", value: img.Summary.ID}}
+	for _, tag := range img.GetTags() {
+		items = append(items, valueItem{label: "Tag", value: tag})
+	}
+	for _, digest := range img.Summary.RepoDigests {
+		items = append(items, valueItem{label: "Digest", value: digest})
+	}
+	return items
+}
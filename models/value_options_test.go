@@ -0,0 +1,43 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ernesto27/dcli/docker"
+
+	"github.com/docker/docker/api/types"
+)
+
+func TestContainerValues(t *testing.T) {
+	container := docker.MyContainer{
+		ID:    "1234567890",
+		Name:  "web",
+		Image: "nginx",
+		Networks: []docker.ContainerEndpoint{
+			{Name: "bridge", IPAddress: "172.17.0.2"},
+			{Name: "backend", IPAddress: "172.18.0.3", IPv6Address: "fd00::3"},
+		},
+		Ports: []types.Port{
+			{IP: "0.0.0.0", PrivatePort: 80, PublicPort: 8080, Type: "tcp"},
+			{PrivatePort: 53, PublicPort: 5353, Type: "udp"},
+		},
+		Env: []string{"PATH=/usr/bin", "DSN=postgres://db:5432/app?sslmode=disable"},
+	}
+
+	want := []valueItem{
+		{label: "ID", value: "1234567890"},
+		{label: "Name", value: "web"},
+		{label: "Image", value: "nginx"},
+		{label: "IP bridge", value: "172.17.0.2"},
+		{label: "IP backend", value: "172.18.0.3"},
+		{label: "IPv6 backend", value: "fd00::3"},
+		{label: "URL", value: "http://localhost:8080"},
+		{label: "Env PATH", value: "/usr/bin"},
+		{label: "Env DSN", value: "postgres://db:5432/app?sslmode=disable"},
+	}
+
+	if got := containerValues(container); !reflect.DeepEqual(got, want) {
+		t.Errorf("containerValues() = %v, want %v", got, want)
+	}
+}
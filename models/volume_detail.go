@@ -2,6 +2,7 @@ package models
 
 import (
 	"fmt"
+	"strconv"
 
 	"github.com/ernesto27/dcli/utils"
 
@@ -56,14 +57,12 @@ func getContentVolume(v docker.MyVolume) string {
 	if len(v.Containers) > 0 {
 		rows := [][]string{}
 		for _, c := range v.Containers {
-			ro := "false"
-			if c.ReadOnly {
-				ro = "true"
+			for _, mount := range c.VolumeMounts(v.Volume.Name) {
+				rows = append(rows, []string{c.Name, mount.Destination, strconv.FormatBool(!mount.RW)})
 			}
-			rows = append(rows, []string{c.Name, c.MountedAt, ro})
 		}
 
-		response += utils.CreateTable("# Containers using this volume", []string{"Name", "Mounted at", "Read -only"}, rows)
+		response += utils.CreateTable("# Containers using this volume", []string{"Name", "Mounted at", "Read-only"}, rows)
 
 	}
 
@@ -88,7 +88,16 @@ func (vl VolumeList) Update(msg tea.Msg, m *model) (table.Model, tea.Cmd) {
 			m.volumeSearch.textInput.SetValue("")
 			m.currentModel = MVolumeSearch
 		case "ctrl+o":
-			m.volumeOptions = NewVolumeOptions(vl.table.SelectedRow()[0])
+			if len(vl.table.SelectedRow()) == 0 {
+				return vl.table, nil
+			}
+
+			v, err := m.dockerClient.GetVolumeByName(vl.table.SelectedRow()[0])
+			if err != nil {
+				fmt.Println(err)
+			}
+
+			m.volumeOptions = NewVolumeOptions(vl.table.SelectedRow()[0], v.Containers)
 			m.currentModel = MVolumeOptions
 		}
 	}
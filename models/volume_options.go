@@ -2,6 +2,9 @@ package models
 
 import (
 	"fmt"
+	"strings"
+
+	"github.com/ernesto27/dcli/docker"
 
 	tea "github.com/charmbracelet/bubbletea"
 )
@@ -10,15 +13,25 @@ type VolumeOptions struct {
 	Options
 }
 
-func NewVolumeOptions(name string) VolumeOptions {
+func NewVolumeOptions(name string, containers []docker.MyContainer) VolumeOptions {
 	choices := []string{Remove}
 
+	disabled := map[string]string{}
+	if len(containers) > 0 {
+		names := []string{}
+		for _, c := range containers {
+			names = append(names, c.Name)
+		}
+		disabled[Remove] = "used by containers: " + strings.Join(names, ", ") + ", remove them first"
+	}
+
 	return VolumeOptions{
 		Options{
-			Cursor:  0,
-			Choice:  "",
-			Choices: choices,
-			Text1:   name,
+			Cursor:   0,
+			Choice:   "",
+			Choices:  choices,
+			Text1:    name,
+			Disabled: disabled,
 		},
 	}
 }
@@ -37,27 +50,28 @@ func (v VolumeOptions) Update(msg tea.Msg, m *model) (VolumeOptions, tea.Cmd) {
 	case tea.KeyMsg:
 		switch msg.String() {
 		case "enter":
-			errAction := false
+			if reason := v.disabledReason(); reason != "" {
+				v.MessageError = reason
+				return v, nil
+			}
+
 			option := m.volumeOptions.Choices[m.volumeOptions.Cursor]
 
 			if option == Remove {
-				err := m.dockerClient.VolumeRemove(m.volumeList.table.SelectedRow()[0])
-				if err != nil {
-					fmt.Println(err)
-					errAction = true
+				if err := m.dockerClient.VolumeRemove(m.volumeList.table.SelectedRow()[0]); err != nil {
+					v.MessageError = err.Error()
+					return v, nil
 				}
 			}
 
-			if !errAction {
-				v, err := m.dockerClient.VolumeList()
-				if err != nil {
-					fmt.Println(err)
-				}
-
-				m.volumeList = NewVolumeList(v, "")
-				m.currentModel = MVolumeList
+			volumes, err := m.dockerClient.VolumeList()
+			if err != nil {
+				fmt.Println(err)
 			}
 
+			m.volumeList = NewVolumeList(volumes, "")
+			m.currentModel = MVolumeList
+			return v, tea.ClearScreen
 		}
 	}
 
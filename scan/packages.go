@@ -0,0 +1,215 @@
+package scan
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+func parseOSRelease(content []byte) OS {
+	release := OS{}
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(scanner.Text(), "=")
+		if !ok {
+			continue
+		}
+		value = strings.Trim(value, `"'`)
+
+		switch key {
+		case "ID":
+			release.ID = value
+		case "VERSION_ID":
+			release.VersionID = value
+		case "PRETTY_NAME":
+			release.PrettyName = value
+		}
+	}
+	return release
+}
+
+// parseDpkgStatus reads the control paragraphs of /var/lib/dpkg/status, skipping packages not fully installed.
+func parseDpkgStatus(content []byte, location string) []Package {
+	packages := []Package{}
+
+	for _, paragraph := range strings.Split(string(content), "\n\n") {
+		fields := map[string]string{}
+		for _, line := range strings.Split(paragraph, "\n") {
+			key, value, ok := strings.Cut(line, ":")
+			if ok && !strings.HasPrefix(line, " ") {
+				fields[key] = strings.TrimSpace(value)
+			}
+		}
+
+		if fields["Package"] == "" {
+			continue
+		}
+		if status, ok := fields["Status"]; ok && !strings.HasSuffix(status, " installed") {
+			continue
+		}
+
+		packages = append(packages, Package{
+			Name:     fields["Package"],
+			Version:  fields["Version"],
+			Type:     TypeDeb,
+			Location: location,
+		})
+	}
+
+	return packages
+}
+
+func parseApkInstalled(content []byte, location string) []Package {
+	packages := []Package{}
+	current := Package{Type: TypeApk, Location: location}
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case line == "":
+			if current.Name != "" {
+				packages = append(packages, current)
+			}
+			current = Package{Type: TypeApk, Location: location}
+		case strings.HasPrefix(line, "P:"):
+			current.Name = line[2:]
+		case strings.HasPrefix(line, "V:"):
+			current.Version = line[2:]
+		}
+	}
+	if current.Name != "" {
+		packages = append(packages, current)
+	}
+
+	return packages
+}
+
+type packageLock struct {
+	Packages map[string]struct {
+		Version string `json:"version"`
+		Name    string `json:"name"`
+	} `json:"packages"`
+	Dependencies map[string]packageLockDependency `json:"dependencies"`
+}
+
+type packageLockDependency struct {
+	Version      string                           `json:"version"`
+	Dependencies map[string]packageLockDependency `json:"dependencies"`
+}
+
+// parsePackageLock supports the "packages" section of lockfile v2/v3 and the nested "dependencies" of v1.
+func parsePackageLock(content []byte, location string) ([]Package, error) {
+	var lock packageLock
+	if err := json.Unmarshal(content, &lock); err != nil {
+		return nil, err
+	}
+
+	packages := []Package{}
+	if len(lock.Packages) > 0 {
+		for key, p := range lock.Packages {
+			if key == "" || p.Version == "" {
+				continue
+			}
+
+			// workspace packages like "packages/a" are not under node_modules, only their name field is used
+			name := p.Name
+			if index := strings.LastIndex(key, "node_modules/"); name == "" && index >= 0 {
+				name = key[index+len("node_modules/"):]
+			}
+			if name == "" {
+				continue
+			}
+			packages = append(packages, Package{Name: name, Version: p.Version, Type: TypeNpm, Location: location})
+		}
+	} else {
+		var walk func(deps map[string]packageLockDependency)
+		walk = func(deps map[string]packageLockDependency) {
+			for name, d := range deps {
+				packages = append(packages, Package{Name: name, Version: d.Version, Type: TypeNpm, Location: location})
+				walk(d.Dependencies)
+			}
+		}
+		walk(lock.Dependencies)
+	}
+
+	sort.Slice(packages, func(i, j int) bool {
+		if packages[i].Name == packages[j].Name {
+			return packages[i].Version < packages[j].Version
+		}
+		return packages[i].Name < packages[j].Name
+	})
+
+	return packages, nil
+}
+
+var requirementRegexp = regexp.MustCompile(`^([A-Za-z0-9][A-Za-z0-9._-]*)(\[[^\]]*\])?\s*(==\s*([^\s;#]+))?`)
+
+func parseRequirements(content []byte, location string) []Package {
+	packages := []Package{}
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "-") {
+			continue
+		}
+
+		match := requirementRegexp.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+
+		packages = append(packages, Package{
+			Name:     strings.ToLower(match[1]),
+			Version:  match[4],
+			Type:     TypePypi,
+			Location: location,
+		})
+	}
+
+	return packages
+}
+
+// PackageURL returns the purl used to identify the package in SBOM documents.
+func PackageURL(p Package, os OS) string {
+	name := p.Name
+	switch p.Type {
+	case TypeDeb, TypeApk, TypeRpm:
+		namespace := os.ID
+		if namespace == "" {
+			namespace = "unknown"
+		}
+		name = namespace + "/" + name
+	case TypeNpm:
+		name = strings.Replace(name, "@", "%40", 1)
+	case TypePypi:
+		name = strings.ToLower(name)
+	}
+
+	// qualifiers are sorted by key, the rpm epoch is a qualifier and not part of the version
+	version := p.Version
+	qualifiers := []string{}
+	if p.Type == TypeDeb || p.Type == TypeApk || p.Type == TypeRpm {
+		if os.VersionID != "" {
+			qualifiers = append(qualifiers, "distro="+os.ID+"-"+os.VersionID)
+		}
+	}
+	if epoch, v, found := strings.Cut(version, ":"); found && p.Type == TypeRpm {
+		version = v
+		qualifiers = append(qualifiers, "epoch="+epoch)
+	}
+
+	purl := "pkg:" + p.Type + "/" + name
+	if version != "" {
+		purl += "@" + version
+	}
+	if len(qualifiers) > 0 {
+		purl += "?" + strings.Join(qualifiers, "&")
+	}
+
+	return purl
+}
@@ -0,0 +1,62 @@
+package scan
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	// the sqlite driver used by go-rpmdb for rpmdb.sqlite, pure go so the binary builds without cgo
+	_ "github.com/glebarez/go-sqlite"
+	rpmdb "github.com/knqyf263/go-rpmdb/pkg"
+)
+
+// readRpmDatabase lists the packages of a BerkeleyDB (RHEL 7/8), NDB (SUSE) or sqlite (Fedora, RHEL 9) rpm database,
+// the database is copied to a temporary file because it can only be opened from a path.
+func readRpmDatabase(name string, r io.Reader) ([]Package, error) {
+	tmp, err := os.CreateTemp("", "dcli-rpmdb-*")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(tmp.Name())
+
+	_, err = io.Copy(tmp, r)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := rpmdb.Open(tmp.Name())
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	infos, err := db.ListPackages()
+	if err != nil {
+		return nil, err
+	}
+
+	packages := []Package{}
+	for _, info := range infos {
+		// gpg-pubkey entries are the imported signing keys, not installed software
+		if info.Name == "gpg-pubkey" {
+			continue
+		}
+		packages = append(packages, Package{Name: info.Name, Version: rpmVersion(info.Epoch, info.Version, info.Release), Type: TypeRpm, Location: name})
+	}
+	return packages, nil
+}
+
+// rpmVersion formats a version like rpm -q, [epoch:]version-release.
+func rpmVersion(epoch *int, version string, release string) string {
+	v := version
+	if release != "" {
+		v += "-" + release
+	}
+	if epoch != nil && *epoch != 0 {
+		v = fmt.Sprintf("%d:%s", *epoch, v)
+	}
+	return v
+}
@@ -0,0 +1,100 @@
+package scan
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+type rpmTag struct {
+	tag   int32
+	value interface{}
+}
+
+// rpmHeader encodes a legacy header blob like the ones stored in the Packages table of rpmdb.sqlite,
+// values are strings (RPM_STRING_TYPE) or int32 (RPM_INT32_TYPE).
+func rpmHeader(tags []rpmTag) []byte {
+	var index, data bytes.Buffer
+	for _, tag := range tags {
+		switch v := tag.value.(type) {
+		case string:
+			binary.Write(&index, binary.BigEndian, []int32{tag.tag, 6, int32(data.Len()), 1})
+			data.WriteString(v + "\x00")
+		case int32:
+			for data.Len()%4 != 0 {
+				data.WriteByte(0)
+			}
+			binary.Write(&index, binary.BigEndian, []int32{tag.tag, 4, int32(data.Len()), 1})
+			binary.Write(&data, binary.BigEndian, v)
+		}
+	}
+
+	var blob bytes.Buffer
+	binary.Write(&blob, binary.BigEndian, []int32{int32(len(tags)), int32(data.Len())})
+	blob.Write(index.Bytes())
+	blob.Write(data.Bytes())
+	return blob.Bytes()
+}
+
+func createRpmDatabase(t *testing.T, headers [][]byte) []byte {
+	file := filepath.Join(t.TempDir(), "rpmdb.sqlite")
+	db, err := sql.Open("sqlite", file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Exec("CREATE TABLE Packages (hnum INTEGER PRIMARY KEY AUTOINCREMENT, blob BLOB NOT NULL)"); err != nil {
+		t.Fatal(err)
+	}
+	for _, h := range headers {
+		if _, err := db.Exec("INSERT INTO Packages (blob) VALUES (?)", h); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := db.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	content, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return content
+}
+
+func TestScanImageRpm(t *testing.T) {
+	rpmDB := createRpmDatabase(t, [][]byte{
+		rpmHeader([]rpmTag{{1000, "bash"}, {1001, "5.1.8"}, {1002, "6.el9"}}),
+		rpmHeader([]rpmTag{{1000, "openssl-libs"}, {1001, "3.0.7"}, {1002, "27.el9"}, {1003, int32(1)}}),
+		rpmHeader([]rpmTag{{1000, "gpg-pubkey"}, {1001, "fd431d51"}, {1002, "4ae0493b"}}),
+	})
+
+	layer := createTar(t, map[string]string{
+		"etc/os-release":                    "ID=\"rhel\"\nVERSION_ID=\"9.3\"\n",
+		"usr/lib/sysimage/rpm/rpmdb.sqlite": string(rpmDB),
+	})
+	archive := createTar(t, map[string]string{
+		"base/layer.tar": string(layer),
+		"manifest.json":  `[{"Layers":["base/layer.tar"]}]`,
+	})
+
+	got, err := ScanImage("ubi:9", bytes.NewReader(archive))
+	if err != nil {
+		t.Fatalf("ScanImage() error = %v", err)
+	}
+
+	want := []Package{
+		{Name: "bash", Version: "5.1.8-6.el9", Type: TypeRpm, Location: "usr/lib/sysimage/rpm/rpmdb.sqlite"},
+		{Name: "openssl-libs", Version: "1:3.0.7-27.el9", Type: TypeRpm, Location: "usr/lib/sysimage/rpm/rpmdb.sqlite"},
+	}
+	if !reflect.DeepEqual(got.Packages, want) || len(got.Warnings) != 0 {
+		t.Errorf("ScanImage() packages = %v, warnings = %v, want %v", got.Packages, got.Warnings, want)
+	}
+
+	if purl := PackageURL(want[1], got.OS); purl != "pkg:rpm/rhel/openssl-libs@3.0.7-27.el9?distro=rhel-9.3&epoch=1" {
+		t.Errorf("PackageURL() = %v", purl)
+	}
+}
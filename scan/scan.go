@@ -0,0 +1,289 @@
+package scan
+
+import (
+	"archive/tar"
+	"bytes"
+	"debug/buildinfo"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/ernesto27/dcli/docker"
+)
+
+const (
+	TypeDeb    = "deb"
+	TypeApk    = "apk"
+	TypeRpm    = "rpm"
+	TypeGolang = "golang"
+	TypeNpm    = "npm"
+	TypePypi   = "pypi"
+)
+
+const (
+	maxManifestSize    = 32 << 20
+	maxBinarySize      = 200 << 20
+	maxRpmDatabaseSize = 512 << 20
+)
+
+type Package struct {
+	Name     string
+	Version  string
+	Type     string
+	Location string
+}
+
+type OS struct {
+	ID         string
+	VersionID  string
+	PrettyName string
+}
+
+type Result struct {
+	Image    string
+	OS       OS
+	Packages []Package
+	Warnings []string
+}
+
+// capturedFile is a file of the final image filesystem, content is only kept for package databases and manifests.
+type capturedFile struct {
+	content  []byte
+	packages []Package
+	rpmDB    bool
+	err      error
+}
+
+type layerContent struct {
+	files   map[string]capturedFile
+	deleted []string
+	opaque  []string
+}
+
+// ScanImage reads a docker save archive of image and detects the OS packages
+// and language dependencies present in its final filesystem.
+func ScanImage(image string, archive io.Reader) (Result, error) {
+	contents := map[string]layerContent{}
+	warnings := []string{}
+
+	// a broken layer is reported and the files read before the error are kept, the scan goes on with the other layers
+	layers, err := docker.ReadImageArchive(archive, func(name string, layer *tar.Reader) error {
+		c, err := readLayer(layer)
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("unable to read the layer %s, the files after the error are missing: %s", name, err))
+		}
+		contents[name] = c
+		return nil
+	})
+	if err != nil {
+		return Result{}, err
+	}
+
+	files := map[string]capturedFile{}
+	for _, name := range layers {
+		c := contents[name]
+		for _, dir := range c.opaque {
+			removePath(files, dir, false)
+		}
+		for _, p := range c.deleted {
+			removePath(files, p, true)
+		}
+		for p, f := range c.files {
+			files[p] = f
+		}
+	}
+
+	result := analyze(image, files)
+	result.Warnings = append(warnings, result.Warnings...)
+	return result, nil
+}
+
+func removePath(files map[string]capturedFile, target string, self bool) {
+	for p := range files {
+		if (self && p == target) || strings.HasPrefix(p, target+"/") {
+			delete(files, p)
+		}
+	}
+}
+
+func readLayer(tr *tar.Reader) (layerContent, error) {
+	c := layerContent{files: map[string]capturedFile{}}
+
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return c, nil
+		}
+		if err != nil {
+			return c, err
+		}
+
+		name := docker.CleanLayerPath(hdr.Name)
+		dir, base := path.Split(name)
+		dir = strings.TrimSuffix(dir, "/")
+
+		if base == ".wh..wh..opq" {
+			c.opaque = append(c.opaque, dir)
+			continue
+		}
+		if strings.HasPrefix(base, ".wh.") {
+			c.deleted = append(c.deleted, path.Join(dir, strings.TrimPrefix(base, ".wh.")))
+			continue
+		}
+
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		f := capturedFile{}
+		switch {
+		case isRpmDatabase(name):
+			f.rpmDB = true
+			if hdr.Size > maxRpmDatabaseSize {
+				f.err = fmt.Errorf("the database is bigger than %d MB", maxRpmDatabaseSize>>20)
+			} else {
+				f.packages, f.err = readRpmDatabase(name, tr)
+			}
+		case isManifest(name) && hdr.Size <= maxManifestSize:
+			f.content, f.err = io.ReadAll(tr)
+		case hdr.FileInfo().Mode()&0111 != 0 && hdr.Size <= maxBinarySize:
+			f.packages, f.err = readGoBinary(name, tr)
+		}
+
+		// files without content are kept so they hide the ones from lower layers
+		c.files[name] = f
+	}
+}
+
+func isManifest(name string) bool {
+	base := path.Base(name)
+	switch {
+	case name == "etc/os-release" || name == "usr/lib/os-release":
+		return true
+	case name == "var/lib/dpkg/status" || strings.HasPrefix(name, "var/lib/dpkg/status.d/"):
+		return true
+	case name == "lib/apk/db/installed":
+		return true
+	case base == "package-lock.json":
+		return true
+	case strings.HasPrefix(base, "requirements") && strings.HasSuffix(base, ".txt"):
+		return true
+	}
+	return false
+}
+
+func isRpmDatabase(name string) bool {
+	switch name {
+	case "var/lib/rpm/Packages", "var/lib/rpm/Packages.db", "var/lib/rpm/rpmdb.sqlite",
+		"usr/lib/sysimage/rpm/Packages", "usr/lib/sysimage/rpm/Packages.db", "usr/lib/sysimage/rpm/rpmdb.sqlite":
+		return true
+	}
+	return false
+}
+
+func readGoBinary(name string, r io.Reader) ([]Package, error) {
+	magic := make([]byte, 4)
+	if _, err := io.ReadFull(r, magic); err != nil {
+		return nil, nil
+	}
+	if !bytes.Equal(magic, []byte("\x7fELF")) {
+		return nil, nil
+	}
+
+	rest, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := buildinfo.Read(bytes.NewReader(append(magic, rest...)))
+	if err != nil {
+		// most executables are not written in go, buildinfo has no exported error for them
+		if err.Error() == "not a Go executable" {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	packages := []Package{
+		{Name: "stdlib", Version: strings.TrimPrefix(info.GoVersion, "go"), Type: TypeGolang, Location: name},
+	}
+	if info.Main.Path != "" {
+		packages = append(packages, Package{Name: info.Main.Path, Version: info.Main.Version, Type: TypeGolang, Location: name})
+	}
+	for _, dep := range info.Deps {
+		if dep.Replace != nil {
+			dep = dep.Replace
+		}
+		packages = append(packages, Package{Name: dep.Path, Version: dep.Version, Type: TypeGolang, Location: name})
+	}
+
+	return packages, nil
+}
+
+func analyze(image string, files map[string]capturedFile) Result {
+	result := Result{Image: image}
+
+	paths := []string{}
+	for p := range files {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+
+	for _, p := range paths {
+		f := files[p]
+		base := path.Base(p)
+
+		switch {
+		case f.rpmDB && f.err != nil:
+			result.Warnings = append(result.Warnings, fmt.Sprintf("unable to read the rpm database /%s: %s", p, f.err))
+		case f.err != nil:
+			result.Warnings = append(result.Warnings, fmt.Sprintf("unable to read /%s: %s", p, f.err))
+		}
+
+		switch {
+		case f.rpmDB || f.packages != nil:
+			result.Packages = append(result.Packages, f.packages...)
+		case f.content == nil:
+			continue
+		case p == "etc/os-release" || p == "usr/lib/os-release":
+			if result.OS.ID == "" {
+				result.OS = parseOSRelease(f.content)
+			}
+		case p == "var/lib/dpkg/status" || strings.HasPrefix(p, "var/lib/dpkg/status.d/"):
+			if !strings.HasSuffix(p, ".md5sums") {
+				result.Packages = append(result.Packages, parseDpkgStatus(f.content, p)...)
+			}
+		case p == "lib/apk/db/installed":
+			result.Packages = append(result.Packages, parseApkInstalled(f.content, p)...)
+		case base == "package-lock.json":
+			pkgs, err := parsePackageLock(f.content, p)
+			if err != nil {
+				result.Warnings = append(result.Warnings, fmt.Sprintf("unable to parse /%s: %s", p, err))
+			}
+			result.Packages = append(result.Packages, pkgs...)
+		default:
+			result.Packages = append(result.Packages, parseRequirements(f.content, p)...)
+		}
+	}
+
+	sort.SliceStable(result.Packages, func(i, j int) bool {
+		a, b := result.Packages[i], result.Packages[j]
+		if a.Type != b.Type {
+			return a.Type < b.Type
+		}
+		return a.Name < b.Name
+	})
+
+	return result
+}
+
+// CountByType returns the number of packages detected for each package type.
+func (r Result) CountByType() map[string]int {
+	count := map[string]int{}
+	for _, p := range r.Packages {
+		count[p.Type]++
+	}
+	return count
+}
@@ -0,0 +1,279 @@
+package scan
+
+import (
+	"archive/tar"
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+func createTar(t *testing.T, files map[string]string) []byte {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for _, name := range sortedKeys(files) {
+		hdr := &tar.Header{Name: name, Mode: 0644, Size: int64(len(files[name])), Typeflag: tar.TypeReg}
+		if err := tw.WriteHeader(hdr); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(files[name])); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func sortedKeys(files map[string]string) []string {
+	keys := []string{}
+	for k := range files {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func TestScanImage(t *testing.T) {
+	base := createTar(t, map[string]string{
+		"etc/os-release": "ID=debian\nVERSION_ID=\"12\"\nPRETTY_NAME=\"Debian GNU/Linux 12 (bookworm)\"\n",
+		"var/lib/dpkg/status": "Package: libc6\nStatus: install ok installed\nVersion: 2.36-9+deb12u3\nDescription: GNU C Library\n multi line\n\n" +
+			"Package: removed\nStatus: deinstall ok config-files\nVersion: 1.0\n",
+		"app/requirements.txt": "django==4.2.1\n",
+	})
+	app := createTar(t, map[string]string{
+		"app/.wh.requirements.txt":  "",
+		"app/requirements-prod.txt": "# prod\nRequests[socks]==2.31.0 ; python_version > '3'\nflask\n",
+		"app/package-lock.json":     `{"lockfileVersion":3,"packages":{"":{"name":"app"},"node_modules/lodash":{"version":"4.17.20"},"node_modules/@types/node":{"version":"20.1.0"}}}`,
+	})
+
+	archive := createTar(t, map[string]string{
+		"base/layer.tar": string(base),
+		"app/layer.tar":  string(app),
+		"manifest.json":  `[{"Layers":["base/layer.tar","app/layer.tar"]}]`,
+	})
+
+	got, err := ScanImage("app:1", bytes.NewReader(archive))
+	if err != nil {
+		t.Fatalf("ScanImage() error = %v", err)
+	}
+
+	wantOS := OS{ID: "debian", VersionID: "12", PrettyName: "Debian GNU/Linux 12 (bookworm)"}
+	if got.OS != wantOS {
+		t.Errorf("ScanImage() os = %v, want %v", got.OS, wantOS)
+	}
+
+	want := []Package{
+		{Name: "libc6", Version: "2.36-9+deb12u3", Type: TypeDeb, Location: "var/lib/dpkg/status"},
+		{Name: "@types/node", Version: "20.1.0", Type: TypeNpm, Location: "app/package-lock.json"},
+		{Name: "lodash", Version: "4.17.20", Type: TypeNpm, Location: "app/package-lock.json"},
+		{Name: "flask", Version: "", Type: TypePypi, Location: "app/requirements-prod.txt"},
+		{Name: "requests", Version: "2.31.0", Type: TypePypi, Location: "app/requirements-prod.txt"},
+	}
+	if !reflect.DeepEqual(got.Packages, want) {
+		t.Errorf("ScanImage() packages = %v, want %v", got.Packages, want)
+	}
+}
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		typ  string
+		a    string
+		b    string
+		want int
+	}{
+		{typ: TypeNpm, a: "1.2.3", b: "1.2.3", want: 0},
+		{typ: TypeGolang, a: "v1.2.3", b: "1.2.3", want: 0},
+		{typ: TypeNpm, a: "1.2.10", b: "1.2.9", want: 1},
+		{typ: TypeNpm, a: "1.2", b: "1.2.1", want: -1},
+		{typ: TypePypi, a: "1.0rc1", b: "1.0", want: -1},
+		{typ: TypePypi, a: "1.0.post1", b: "1.0", want: 1},
+		{typ: TypeNpm, a: "1.0.0-beta", b: "1.0.0", want: -1},
+		{typ: TypeNpm, a: "1.0.0+build5", b: "1.0.0", want: 0},
+		{typ: TypeDeb, a: "1.0~rc1", b: "1.0", want: -1},
+		{typ: TypeDeb, a: "1:1.0", b: "2.0", want: 1},
+		{typ: TypeDeb, a: "2.36-9+deb12u3", b: "2.36-9+deb12u4", want: -1},
+		{typ: TypeDeb, a: "2.36-9", b: "2.36-9+deb12u3", want: -1},
+		{typ: TypeDeb, a: "1.1.1", b: "1.1.1a", want: -1},
+		{typ: TypeDeb, a: "3.0.11-1~deb12u2", b: "3.0.11-1", want: -1},
+		{typ: TypeDeb, a: "1.2.3-1", b: "1.2.3-1.1", want: -1},
+		{typ: TypeRpm, a: "1.1.1", b: "1.1.1a", want: -1},
+		{typ: TypeRpm, a: "1:3.0.7-27.el9", b: "1:3.0.7-24.el9", want: 1},
+		{typ: TypeRpm, a: "5.1.8-6.el9", b: "5.1.8-6.el9_1", want: -1},
+		{typ: TypeRpm, a: "1.0~rc1-1", b: "1.0-1", want: -1},
+		{typ: TypeRpm, a: "1.0^git1-1", b: "1.0-1", want: 1},
+		{typ: TypeRpm, a: "1.0a-1", b: "1.0.1-1", want: -1},
+		{typ: TypeApk, a: "3.0.2-r1", b: "3.0.2-r0", want: 1},
+		{typ: TypeApk, a: "1.1.1", b: "1.1.1a", want: -1},
+		{typ: TypeApk, a: "1.2.3_rc1-r0", b: "1.2.3-r0", want: -1},
+		{typ: TypeApk, a: "1.2.3_p1-r0", b: "1.2.3-r0", want: 1},
+		{typ: TypeApk, a: "1.36.1-r10", b: "1.36.1-r9", want: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.typ+" "+tt.a+" "+tt.b, func(t *testing.T) {
+			if got := CompareVersions(tt.typ, tt.a, tt.b); got != tt.want {
+				t.Errorf("CompareVersions() = got %v, want %v", got, tt.want)
+			}
+			if got := CompareVersions(tt.typ, tt.b, tt.a); got != -tt.want {
+				t.Errorf("CompareVersions() reversed = got %v, want %v", got, -tt.want)
+			}
+		})
+	}
+}
+
+func TestIsAffected(t *testing.T) {
+	tests := []struct {
+		name    string
+		version string
+		v       Vulnerability
+		want    bool
+	}{
+		{
+			name:    "should match a deb version without the security update",
+			version: "2.36-9",
+			v:       Vulnerability{Type: TypeDeb, Fixed: "2.36-9+deb12u3"},
+			want:    true,
+		},
+		{
+			name:    "should match an openssl version before the letter release",
+			version: "1.1.1",
+			v:       Vulnerability{Type: TypeDeb, Fixed: "1.1.1a"},
+			want:    true,
+		},
+		{
+			name:    "should not match the fixed version",
+			version: "1.1.1a",
+			v:       Vulnerability{Type: TypeApk, Fixed: "1.1.1a"},
+		},
+		{
+			name:    "should match an rpm release before the fix",
+			version: "1:3.0.7-24.el9",
+			v:       Vulnerability{Type: TypeRpm, Fixed: "1:3.0.7-25.el9"},
+			want:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isAffected(tt.version, tt.v); got != tt.want {
+				t.Errorf("isAffected() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatch(t *testing.T) {
+	packages := []Package{
+		{Name: "lodash", Version: "4.17.20", Type: TypeNpm},
+		{Name: "libc6", Version: "2.36-9+deb12u3", Type: TypeDeb},
+		{Name: "requests", Version: "2.31.0", Type: TypePypi},
+	}
+	db := []Vulnerability{
+		{ID: "CVE-2021-23337", Type: TypeNpm, Package: "lodash", Severity: "high", Fixed: "4.17.21"},
+		{ID: "CVE-2023-4911", Type: TypeDeb, Package: "libc6", Severity: "CRITICAL", Introduced: "2.34", Fixed: "2.36-9+deb12u3"},
+		{ID: "CVE-2024-0001", Type: TypeDeb, Package: "libc6", Severity: "low", Versions: []string{"2.36-9+deb12u3"}},
+		{ID: "CVE-2023-0002", Type: TypeNpm, Package: "lodash", Severity: "whatever", Introduced: "4.0.0"},
+		{ID: "CVE-2023-0003", Type: TypeNpm, Package: "requests", Severity: "HIGH"},
+	}
+
+	got := []string{}
+	for _, f := range Match(packages, db) {
+		got = append(got, f.ID+" "+f.Severity)
+	}
+
+	want := []string{"CVE-2021-23337 HIGH", "CVE-2024-0001 LOW", "CVE-2023-0002 UNKNOWN"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Match() = %v, want %v", got, want)
+	}
+}
+
+func TestWriteSBOM(t *testing.T) {
+	result := Result{
+		Image:    "app:1",
+		OS:       OS{ID: "alpine", VersionID: "3.18.0"},
+		Packages: []Package{{Name: "musl", Version: "1.2.4-r0", Type: TypeApk, Location: "lib/apk/db/installed"}},
+	}
+	created := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	for _, format := range []string{FormatSPDX, FormatCycloneDX} {
+		t.Run(format, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := WriteSBOM(&buf, format, result, created); err != nil {
+				t.Fatalf("WriteSBOM() error = %v", err)
+			}
+
+			var doc map[string]any
+			if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+				t.Fatalf("WriteSBOM() invalid json: %v", err)
+			}
+			if !strings.Contains(buf.String(), "pkg:apk/alpine/musl@1.2.4-r0?distro=alpine-3.18.0") {
+				t.Errorf("WriteSBOM() missing purl in %s", buf.String())
+			}
+		})
+	}
+
+	if err := WriteSBOM(&bytes.Buffer{}, "xml", result, created); err == nil {
+		t.Errorf("WriteSBOM() expected error for unknown format")
+	}
+}
+
+func TestParsePackageLock(t *testing.T) {
+	lock := `{"lockfileVersion":3,"packages":{
+		"":{"name":"monorepo","version":"1.0.0"},
+		"node_modules/a":{"resolved":"packages/a","link":true},
+		"packages/a":{"name":"@acme/a","version":"0.1.0"},
+		"pkg/b":{"version":"0.2.0"},
+		"packages/a/node_modules/lodash":{"version":"4.17.21"}
+	}}`
+
+	got, err := parsePackageLock([]byte(lock), "app/package-lock.json")
+	if err != nil {
+		t.Fatalf("parsePackageLock() error = %v", err)
+	}
+
+	want := []Package{
+		{Name: "@acme/a", Version: "0.1.0", Type: TypeNpm, Location: "app/package-lock.json"},
+		{Name: "lodash", Version: "4.17.21", Type: TypeNpm, Location: "app/package-lock.json"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parsePackageLock() = %v, want %v", got, want)
+	}
+}
+
+func TestScanImageBrokenLayer(t *testing.T) {
+	base := createTar(t, map[string]string{
+		"var/lib/dpkg/status": "Package: libc6\nStatus: install ok installed\nVersion: 2.36-9+deb12u3\n",
+	})
+	app := createTar(t, map[string]string{
+		"app/package-lock.json": `{"lockfileVersion":3,"packages":{"node_modules/lodash":{"version":"4.17.20"}}}`,
+		"app/data.bin":          strings.Repeat("0", 4096),
+	})
+
+	// the content of app/data.bin is cut after its header
+	archive := createTar(t, map[string]string{
+		"base/layer.tar": string(base),
+		"app/layer.tar":  string(app[:512*2+100]),
+		"manifest.json":  `[{"Layers":["base/layer.tar","app/layer.tar"]}]`,
+	})
+
+	got, err := ScanImage("app:1", bytes.NewReader(archive))
+	if err != nil {
+		t.Fatalf("ScanImage() error = %v", err)
+	}
+
+	if len(got.Warnings) != 1 || !strings.Contains(got.Warnings[0], "app/layer.tar") {
+		t.Errorf("ScanImage() warnings = %v, want the broken layer", got.Warnings)
+	}
+
+	want := []Package{
+		{Name: "libc6", Version: "2.36-9+deb12u3", Type: TypeDeb, Location: "var/lib/dpkg/status"},
+	}
+	if !reflect.DeepEqual(got.Packages, want) {
+		t.Errorf("ScanImage() packages = %v, want %v", got.Packages, want)
+	}
+}
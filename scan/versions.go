@@ -0,0 +1,445 @@
+package scan
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// CompareVersions compares two versions of a package of type typ with the ordering of its package manager,
+// dpkg for deb, rpm for rpm and apk-tools for apk. Language packages are compared like semver.
+func CompareVersions(typ string, a string, b string) int {
+	switch typ {
+	case TypeDeb:
+		return compareDebVersions(a, b)
+	case TypeRpm:
+		return compareRpmVersions(a, b)
+	case TypeApk:
+		return compareApkVersions(a, b)
+	}
+	return compareGenericVersions(a, b)
+}
+
+func sign(n int) int {
+	switch {
+	case n < 0:
+		return -1
+	case n > 0:
+		return 1
+	}
+	return 0
+}
+
+func compareInts(a int, b int) int {
+	return sign(a - b)
+}
+
+// splitVersion splits [epoch:]version[-release], the release starts after the last hyphen.
+func splitVersion(version string) (int, string, string) {
+	epoch, rest := splitEpoch(version)
+	if i := strings.LastIndex(rest, "-"); i >= 0 {
+		return epoch, rest[:i], rest[i+1:]
+	}
+	return epoch, rest, ""
+}
+
+func splitEpoch(version string) (int, string) {
+	version = strings.TrimSpace(version)
+	if epoch, rest, ok := strings.Cut(version, ":"); ok {
+		if n, err := strconv.Atoi(epoch); err == nil {
+			return n, rest
+		}
+	}
+	return 0, version
+}
+
+func compareDebVersions(a string, b string) int {
+	epochA, versionA, revisionA := splitVersion(a)
+	epochB, versionB, revisionB := splitVersion(b)
+	if c := compareInts(epochA, epochB); c != 0 {
+		return c
+	}
+	if c := dpkgCompare(versionA, versionB); c != 0 {
+		return c
+	}
+	return dpkgCompare(revisionA, revisionB)
+}
+
+// dpkgOrder is the weight of a character outside of the digits, ~ sorts before the end of the string
+// and letters before the other characters.
+func dpkgOrder(s string, i int) int {
+	if i >= len(s) {
+		return 0
+	}
+	c := s[i]
+	switch {
+	case isDigit(c):
+		return 0
+	case isLetter(c):
+		return int(c)
+	case c == '~':
+		return -1
+	}
+	return int(c) + 256
+}
+
+// dpkgCompare is verrevcmp of dpkg, it alternates between non digit and digit parts.
+func dpkgCompare(a string, b string) int {
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		for (i < len(a) && !isDigit(a[i])) || (j < len(b) && !isDigit(b[j])) {
+			if c := dpkgOrder(a, i) - dpkgOrder(b, j); c != 0 {
+				return sign(c)
+			}
+			i++
+			j++
+		}
+
+		for i < len(a) && a[i] == '0' {
+			i++
+		}
+		for j < len(b) && b[j] == '0' {
+			j++
+		}
+
+		firstDiff := 0
+		for i < len(a) && isDigit(a[i]) && j < len(b) && isDigit(b[j]) {
+			if firstDiff == 0 {
+				firstDiff = int(a[i]) - int(b[j])
+			}
+			i++
+			j++
+		}
+		if i < len(a) && isDigit(a[i]) {
+			return 1
+		}
+		if j < len(b) && isDigit(b[j]) {
+			return -1
+		}
+		if firstDiff != 0 {
+			return sign(firstDiff)
+		}
+	}
+	return 0
+}
+
+func compareRpmVersions(a string, b string) int {
+	epochA, versionA, releaseA := splitVersion(a)
+	epochB, versionB, releaseB := splitVersion(b)
+	if c := compareInts(epochA, epochB); c != 0 {
+		return c
+	}
+	if c := rpmCompare(versionA, versionB); c != 0 {
+		return c
+	}
+	return rpmCompare(releaseA, releaseB)
+}
+
+// rpmCompare is rpmvercmp, it compares the alphanumeric segments of both versions,
+// ~ sorts before everything and ^ after the end of the version but before any other segment.
+func rpmCompare(a string, b string) int {
+	if a == b {
+		return 0
+	}
+
+	isSeparator := func(c byte) bool {
+		return !isDigit(c) && !isLetter(c) && c != '~' && c != '^'
+	}
+
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		for i < len(a) && isSeparator(a[i]) {
+			i++
+		}
+		for j < len(b) && isSeparator(b[j]) {
+			j++
+		}
+
+		tildeA, tildeB := i < len(a) && a[i] == '~', j < len(b) && b[j] == '~'
+		if tildeA || tildeB {
+			if !tildeA {
+				return 1
+			}
+			if !tildeB {
+				return -1
+			}
+			i++
+			j++
+			continue
+		}
+
+		caretA, caretB := i < len(a) && a[i] == '^', j < len(b) && b[j] == '^'
+		if caretA || caretB {
+			if i >= len(a) {
+				return -1
+			}
+			if j >= len(b) {
+				return 1
+			}
+			if !caretA {
+				return 1
+			}
+			if !caretB {
+				return -1
+			}
+			i++
+			j++
+			continue
+		}
+
+		if i >= len(a) || j >= len(b) {
+			break
+		}
+
+		number := isDigit(a[i])
+		segment := func(s string, k int) (string, int) {
+			start := k
+			for k < len(s) && ((number && isDigit(s[k])) || (!number && isLetter(s[k]))) {
+				k++
+			}
+			return s[start:k], k
+		}
+
+		var segA, segB string
+		segA, i = segment(a, i)
+		segB, j = segment(b, j)
+
+		// a number is newer than letters
+		if segB == "" {
+			if number {
+				return 1
+			}
+			return -1
+		}
+
+		if number {
+			if c := compareNumbers(segA, segB); c != 0 {
+				return c
+			}
+			continue
+		}
+		if c := strings.Compare(segA, segB); c != 0 {
+			return c
+		}
+	}
+
+	switch {
+	case i >= len(a) && j >= len(b):
+		return 0
+	case i >= len(a):
+		return -1
+	}
+	return 1
+}
+
+// apkSuffixes are the suffixes of apk versions in order, the ones before "" are pre-releases.
+var apkSuffixes = []string{"alpha", "beta", "pre", "rc", "", "cvs", "svn", "git", "hg", "p"}
+
+type apkVersion struct {
+	numbers  []string
+	letter   string
+	suffixes []apkSuffix
+	revision string
+}
+
+type apkSuffix struct {
+	rank   int
+	number string
+}
+
+// parseApkVersion parses versions like 1.2.3a_rc1_p2-r4.
+func parseApkVersion(version string) apkVersion {
+	v := apkVersion{}
+	version, v.revision, _ = strings.Cut(version, "-r")
+	version, _, _ = strings.Cut(version, "~")
+
+	parts := strings.Split(version, "_")
+	numbers := parts[0]
+	for numbers != "" {
+		end := 0
+		for end < len(numbers) && isDigit(numbers[end]) {
+			end++
+		}
+		if end == 0 {
+			v.letter = numbers
+			break
+		}
+		v.numbers = append(v.numbers, numbers[:end])
+		numbers = strings.TrimPrefix(numbers[end:], ".")
+	}
+
+	for _, part := range parts[1:] {
+		name := strings.TrimRightFunc(part, unicode.IsDigit)
+		rank := len(apkSuffixes)
+		for i, s := range apkSuffixes {
+			if s == name && s != "" {
+				rank = i
+			}
+		}
+		v.suffixes = append(v.suffixes, apkSuffix{rank: rank, number: part[len(name):]})
+	}
+
+	return v
+}
+
+func compareApkVersions(a string, b string) int {
+	va, vb := parseApkVersion(a), parseApkVersion(b)
+
+	for i := 0; i < len(va.numbers) && i < len(vb.numbers); i++ {
+		if c := compareNumbers(va.numbers[i], vb.numbers[i]); c != 0 {
+			return c
+		}
+	}
+	if c := compareInts(len(va.numbers), len(vb.numbers)); c != 0 {
+		return c
+	}
+
+	if c := strings.Compare(va.letter, vb.letter); c != 0 {
+		return c
+	}
+
+	release := apkSuffix{rank: 4}
+	for i := 0; i < len(va.suffixes) || i < len(vb.suffixes); i++ {
+		sa, sb := release, release
+		if i < len(va.suffixes) {
+			sa = va.suffixes[i]
+		}
+		if i < len(vb.suffixes) {
+			sb = vb.suffixes[i]
+		}
+		if c := compareInts(sa.rank, sb.rank); c != 0 {
+			return c
+		}
+		if c := compareNumbers(sa.number, sb.number); c != 0 {
+			return c
+		}
+	}
+
+	return compareNumbers(va.revision, vb.revision)
+}
+
+// compareGenericVersions compares versions of the form [epoch:]1.2.3[-suffix] segment by segment,
+// numbers numerically and text lexically. Pre-release suffixes like ~rc1 or -beta sort before the release,
+// post releases like .post1 after it and build metadata after + is ignored like in semver.
+func compareGenericVersions(a string, b string) int {
+	epochA, restA := splitEpoch(strings.TrimPrefix(strings.TrimSpace(a), "v"))
+	epochB, restB := splitEpoch(strings.TrimPrefix(strings.TrimSpace(b), "v"))
+	if c := compareInts(epochA, epochB); c != 0 {
+		return c
+	}
+	restA, _, _ = strings.Cut(restA, "+")
+	restB, _, _ = strings.Cut(restB, "+")
+
+	ta, tb := tokenizeVersion(restA), tokenizeVersion(restB)
+	for i := 0; i < len(ta) || i < len(tb); i++ {
+		if i >= len(ta) {
+			return -trailingOrder(tb[i])
+		}
+		if i >= len(tb) {
+			return trailingOrder(ta[i])
+		}
+
+		if c := compareToken(ta[i], tb[i]); c != 0 {
+			return c
+		}
+	}
+
+	return 0
+}
+
+func tokenizeVersion(version string) []string {
+	tokens := []string{}
+	current := []rune{}
+	digits := false
+
+	flush := func() {
+		if len(current) > 0 {
+			tokens = append(tokens, string(current))
+			current = current[:0]
+		}
+	}
+
+	for _, r := range version {
+		switch {
+		case r == '~':
+			flush()
+			tokens = append(tokens, "~")
+		case unicode.IsDigit(r):
+			if !digits {
+				flush()
+			}
+			digits = true
+			current = append(current, r)
+		case unicode.IsLetter(r):
+			if digits {
+				flush()
+			}
+			digits = false
+			current = append(current, r)
+		default:
+			flush()
+			digits = false
+		}
+	}
+	flush()
+
+	return tokens
+}
+
+// trailingOrder returns the order of a version that has token left over the other version, 1.0.1 > 1.0 and 1.0.post1 > 1.0 but 1.0rc1 < 1.0.
+func trailingOrder(token string) int {
+	if token == "post" || isNumber(token) {
+		return 1
+	}
+	return -1
+}
+
+func compareToken(a string, b string) int {
+	if a == b {
+		return 0
+	}
+	if a == "~" {
+		return -1
+	}
+	if b == "~" {
+		return 1
+	}
+
+	numberA, numberB := isNumber(a), isNumber(b)
+	switch {
+	case numberA && numberB:
+		return compareNumbers(a, b)
+	case numberA:
+		return 1
+	case numberB:
+		return -1
+	}
+
+	return strings.Compare(a, b)
+}
+
+// compareNumbers compares strings of digits of any length, an empty string is 0.
+func compareNumbers(a string, b string) int {
+	a, b = strings.TrimLeft(a, "0"), strings.TrimLeft(b, "0")
+	if len(a) != len(b) {
+		return compareInts(len(a), len(b))
+	}
+	return strings.Compare(a, b)
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isLetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isNumber(s string) bool {
+	for _, r := range s {
+		if !unicode.IsDigit(r) {
+			return false
+		}
+	}
+	return s != ""
+}
@@ -0,0 +1,125 @@
+package scan
+
+import (
+	"encoding/json"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var severities = []string{"CRITICAL", "HIGH", "MEDIUM", "LOW", "UNKNOWN"}
+
+// Vulnerability is an entry of the offline database, a json array of these objects.
+// A package is affected when its version is listed in Versions, or when it is
+// greater or equal than Introduced and lower than Fixed.
+type Vulnerability struct {
+	ID         string   `json:"id"`
+	Type       string   `json:"type"`
+	Package    string   `json:"package"`
+	Severity   string   `json:"severity"`
+	Introduced string   `json:"introduced"`
+	Fixed      string   `json:"fixed"`
+	Versions   []string `json:"versions"`
+	Summary    string   `json:"summary"`
+}
+
+type Finding struct {
+	Vulnerability
+	Installed Package
+}
+
+func LoadDatabase(path string) ([]Vulnerability, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	db := []Vulnerability{}
+	if err := json.Unmarshal(data, &db); err != nil {
+		return nil, err
+	}
+	return db, nil
+}
+
+func Match(packages []Package, db []Vulnerability) []Finding {
+	index := map[string][]Vulnerability{}
+	for _, v := range db {
+		key := v.Type + "/" + strings.ToLower(v.Package)
+		index[key] = append(index[key], v)
+	}
+
+	findings := []Finding{}
+	for _, p := range packages {
+		for _, v := range index[p.Type+"/"+strings.ToLower(p.Name)] {
+			if isAffected(p.Version, v) {
+				v.Severity = NormalizeSeverity(v.Severity)
+				findings = append(findings, Finding{Vulnerability: v, Installed: p})
+			}
+		}
+	}
+
+	sort.SliceStable(findings, func(i, j int) bool {
+		a, b := severityRank(findings[i].Severity), severityRank(findings[j].Severity)
+		if a != b {
+			return a < b
+		}
+		return findings[i].ID < findings[j].ID
+	})
+
+	return findings
+}
+
+// CountBySeverity returns the number of findings for each severity, ordered from critical to unknown.
+func CountBySeverity(findings []Finding) [][2]string {
+	count := map[string]int{}
+	for _, f := range findings {
+		count[f.Severity]++
+	}
+
+	rows := [][2]string{}
+	for _, s := range severities {
+		rows = append(rows, [2]string{s, strconv.Itoa(count[s])})
+	}
+	return rows
+}
+
+func NormalizeSeverity(severity string) string {
+	severity = strings.ToUpper(strings.TrimSpace(severity))
+	if severityRank(severity) == len(severities)-1 {
+		return "UNKNOWN"
+	}
+	return severity
+}
+
+func severityRank(severity string) int {
+	for i, s := range severities {
+		if s == severity {
+			return i
+		}
+	}
+	return len(severities) - 1
+}
+
+func isAffected(version string, v Vulnerability) bool {
+	if version == "" {
+		return false
+	}
+
+	if len(v.Versions) > 0 {
+		for _, affected := range v.Versions {
+			if CompareVersions(v.Type, version, affected) == 0 {
+				return true
+			}
+		}
+		return false
+	}
+
+	if v.Introduced != "" && CompareVersions(v.Type, version, v.Introduced) < 0 {
+		return false
+	}
+	if v.Fixed != "" && CompareVersions(v.Type, version, v.Fixed) >= 0 {
+		return false
+	}
+	return true
+}
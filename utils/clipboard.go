@@ -0,0 +1,35 @@
+package utils
+
+import (
+	"errors"
+	"os"
+	"strings"
+
+	"github.com/atotto/clipboard"
+	"github.com/aymanbagabas/go-osc52/v2"
+)
+
+var ErrNoClipboard = errors.New("no clipboard available")
+
+// CopyToClipboard copies value to the system clipboard, when there is none (like in a ssh session)
+// the terminal is asked to do it with an OSC52 escape sequence.
+func CopyToClipboard(value string) error {
+	if err := clipboard.WriteAll(value); err == nil {
+		return nil
+	}
+
+	term := os.Getenv("TERM")
+	if term == "" || term == "dumb" || term == "linux" {
+		return ErrNoClipboard
+	}
+
+	seq := osc52.New(value)
+	if os.Getenv("TMUX") != "" {
+		seq = seq.Tmux()
+	} else if strings.HasPrefix(term, "screen") {
+		seq = seq.Screen()
+	}
+
+	_, err := seq.WriteTo(os.Stderr)
+	return err
+}
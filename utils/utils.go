@@ -95,3 +95,80 @@ func FormatSize(size int64) string {
 		return fmt.Sprintf("%d bytes", size)
 	}
 }
+
+// WrapText splits s in lines of at most width runes, breaking on spaces when possible.
+// The spacing of the text is kept, only the space where a line is broken is removed.
+func WrapText(s string, width int) []string {
+	lines := []string{}
+
+	for _, paragraph := range strings.Split(s, "\n") {
+		rest := []rune(paragraph)
+		for len(rest) > width {
+			end := width
+			for i := width; i > 0; i-- {
+				if rest[i] == ' ' {
+					end = i
+					break
+				}
+			}
+
+			lines = append(lines, string(rest[:end]))
+			rest = rest[end:]
+			if end < width || rest[0] == ' ' {
+				rest = rest[1:]
+			}
+		}
+		lines = append(lines, string(rest))
+	}
+
+	return lines
+}
+
+// SplitCommand splits a command line in arguments, single and double quotes group words and backslash escapes the next character.
+func SplitCommand(command string) ([]string, error) {
+	args := []string{}
+	current := strings.Builder{}
+	inArg := false
+	var quote rune
+	escaped := false
+
+	for _, r := range command {
+		switch {
+		case escaped:
+			current.WriteRune(r)
+			escaped = false
+		case r == '\\' && quote != '\'':
+			escaped = true
+			inArg = true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				current.WriteRune(r)
+			}
+		case r == '"' || r == '\'':
+			quote = r
+			inArg = true
+		case r == ' ' || r == '\t' || r == '\n':
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+		default:
+			current.WriteRune(r)
+			inArg = true
+		}
+	}
+
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated quote %c in command", quote)
+	}
+	if escaped {
+		return nil, fmt.Errorf("command ends with a backslash")
+	}
+	if inArg {
+		args = append(args, current.String())
+	}
+	return args, nil
+}
@@ -1,6 +1,7 @@
 package utils
 
 import (
+	"reflect"
 	"testing"
 
 	"github.com/docker/docker/api/types/image"
@@ -230,3 +231,103 @@ func TestFormatSize(t *testing.T) {
 		})
 	}
 }
+
+func TestWrapText(t *testing.T) {
+	tests := []struct {
+		name  string
+		text  string
+		width int
+		want  []string
+	}{
+		{
+			name:  "should keep short text in one line",
+			text:  "nginx -g daemon off;",
+			width: 40,
+			want:  []string{"nginx -g daemon off;"},
+		},
+		{
+			name:  "should break on spaces",
+			text:  "one two three four",
+			width: 9,
+			want:  []string{"one two", "three", "four"},
+		},
+		{
+			name:  "should split words longer than width",
+			text:  "PATH=/usr/local/sbin:/usr/local/bin end",
+			width: 10,
+			want:  []string{"PATH=/usr/", "local/sbin", ":/usr/loca", "l/bin end"},
+		},
+		{
+			name:  "should keep the spacing of values",
+			text:  "LABEL=a  b   c",
+			width: 20,
+			want:  []string{"LABEL=a  b   c"},
+		},
+		{
+			name:  "should split multi-byte characters as a whole",
+			text:  "año=ñandú",
+			width: 4,
+			want:  []string{"año=", "ñand", "ú"},
+		},
+		{
+			name:  "should keep line breaks",
+			text:  "first\nsecond",
+			width: 20,
+			want:  []string{"first", "second"},
+		},
+		{
+			name:  "should return one empty line for empty text",
+			text:  "",
+			width: 10,
+			want:  []string{""},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := WrapText(tt.text, tt.width); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("WrapText() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSplitCommand(t *testing.T) {
+	tests := []struct {
+		name    string
+		command string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name:    "should split on spaces",
+			command: "ls  -la /tmp",
+			want:    []string{"ls", "-la", "/tmp"},
+		},
+		{
+			name:    "should group quoted words",
+			command: `sh -c "echo 'hello world' && env" ''`,
+			want:    []string{"sh", "-c", "echo 'hello world' && env", ""},
+		},
+		{
+			name:    "should escape characters",
+			command: `echo a\ b "c\"d"`,
+			want:    []string{"echo", "a b", `c"d`},
+		},
+		{
+			name:    "should fail with unterminated quote",
+			command: `echo "hello`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SplitCommand(tt.command)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SplitCommand() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SplitCommand() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}